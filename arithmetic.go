@@ -0,0 +1,94 @@
+package times
+
+import (
+	"time"
+)
+
+// Overflow это правило для дня, которого нет в полученном месяце
+type Overflow int
+
+const (
+	// OverflowClamp использует последний день месяца: 31 января + 1 месяц = 28 февраля
+	OverflowClamp Overflow = iota
+
+	// OverflowNormalize переносит лишние дни на следующий месяц,
+	// как time.Time.AddDate: 31 января + 1 месяц = 3 марта
+	OverflowNormalize
+
+	// OverflowError возвращает *DateOverflowError
+	OverflowError
+)
+
+// DatePolicy это правило прибавления лет и месяцев
+type DatePolicy struct {
+	// Overflow это правило для дня, которого нет в полученном месяце
+	Overflow Overflow
+
+	// PreserveEndOfMonth сохраняет последний день месяца:
+	// 28 февраля + 1 месяц = 31 марта, 30 апреля + 1 месяц = 31 мая
+	PreserveEndOfMonth bool
+}
+
+var (
+	// Clamp это правило по умолчанию, см. OverflowClamp
+	Clamp = DatePolicy{Overflow: OverflowClamp}
+
+	// Normalize это правило time.Time.AddDate, см. OverflowNormalize
+	Normalize = DatePolicy{Overflow: OverflowNormalize}
+
+	// Strict возвращает ошибку для несуществующего дня, см. OverflowError
+	Strict = DatePolicy{Overflow: OverflowError}
+
+	// EndOfMonthSticky сохраняет последний день месяца, остальные дни ограничиваются концом месяца
+	EndOfMonthSticky = DatePolicy{Overflow: OverflowClamp, PreserveEndOfMonth: true}
+)
+
+// AddDate возвращает метку времени через years лет, months месяцев и days дней
+//
+// Годы и месяцы прибавляются по правилу policy, по умолчанию Clamp, затем прибавляются дни.
+// Вычисления выполняются по календарю в часовом поясе t с сохранением времени суток,
+// поэтому день перехода на летнее или зимнее время считается одним днём.
+// Ошибка возможна только для OverflowError
+func (t Time) AddDate(years, months, days int, policy ...DatePolicy) (Time, error) {
+	p := Clamp
+	if len(policy) > 0 {
+		p = policy[0]
+	}
+	value := t.Time()
+	year, month, day := value.Date()
+	hour, minute, second := value.Clock()
+
+	first := time.Date(year, month+time.Month(years*12+months), 1, 0, 0, 0, 0, time.UTC)
+	targetYear, targetMonth, _ := first.Date()
+	last := daysIn(targetYear, targetMonth)
+	switch {
+	case p.PreserveEndOfMonth && day == daysIn(year, month):
+		day = last
+	case day <= last:
+	case p.Overflow == OverflowClamp:
+		day = last
+	case p.Overflow == OverflowError:
+		return Time{}, &DateOverflowError{
+			Date: Date{Year: targetYear, Month: targetMonth, Day: day},
+		}
+	}
+	value = time.Date(targetYear, targetMonth, day+days, hour, minute, second, value.Nanosecond(), value.Location())
+	return Time(value), nil
+}
+
+// AddYears возвращает метку времени через years лет, см. AddDate
+func (t Time) AddYears(years int, policy ...DatePolicy) (Time, error) {
+	return t.AddDate(years, 0, 0, policy...)
+}
+
+// AddMonths возвращает метку времени через months месяцев, см. AddDate
+func (t Time) AddMonths(months int, policy ...DatePolicy) (Time, error) {
+	return t.AddDate(0, months, 0, policy...)
+}
+
+// AddDays возвращает метку времени через days дней с сохранением времени суток
+// День перехода на летнее или зимнее время считается одним днём
+func (t Time) AddDays(days int) Time {
+	value := t.Time()
+	return Time(value.AddDate(0, 0, days))
+}
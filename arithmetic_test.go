@@ -0,0 +1,76 @@
+package times
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAddDate(t *testing.T) {
+	Convey("Проверяем прибавление месяцев", t, func() {
+		january := Time(time.Date(2018, 1, 31, 10, 30, 0, 0, MoscowLocation))
+
+		testAddMonths(january, 1, nil, "2018-02-28T10:30:00+03:00")
+		testAddMonths(january, 1, []DatePolicy{Clamp}, "2018-02-28T10:30:00+03:00")
+		testAddMonths(january, 1, []DatePolicy{Normalize}, "2018-03-03T10:30:00+03:00")
+		testAddMonths(january, 2, []DatePolicy{Strict}, "2018-03-31T10:30:00+03:00")
+		testAddMonths(january, -2, nil, "2017-11-30T10:30:00+03:00")
+
+		_, err := january.AddMonths(1, Strict)
+		So(err, ShouldNotBeNil)
+		var overflow *DateOverflowError
+		So(errors.As(err, &overflow), ShouldBeTrue)
+		So(overflow.Date, ShouldResemble, Date{Year: 2018, Month: time.February, Day: 31})
+		So(err.Error(), ShouldEqual, "times: day 31 does not exist in 2018-02")
+	})
+	Convey("Проверяем сохранение последнего дня месяца", t, func() {
+		february := Time(time.Date(2018, 2, 28, 0, 0, 0, 0, MoscowLocation))
+		testAddMonths(february, 1, nil, "2018-03-28T00:00:00+03:00")
+		testAddMonths(february, 1, []DatePolicy{EndOfMonthSticky}, "2018-03-31T00:00:00+03:00")
+		testAddMonths(february, 2, []DatePolicy{EndOfMonthSticky}, "2018-04-30T00:00:00+03:00")
+
+		middle := Time(time.Date(2018, 2, 27, 0, 0, 0, 0, MoscowLocation))
+		testAddMonths(middle, 1, []DatePolicy{EndOfMonthSticky}, "2018-03-27T00:00:00+03:00")
+	})
+	Convey("Проверяем годы и дни", t, func() {
+		leap := Time(time.Date(2016, 2, 29, 12, 0, 0, 0, MoscowLocation))
+		result, err := leap.AddYears(1)
+		So(err, ShouldBeNil)
+		So(result.String(), ShouldEqual, "2017-02-28T12:00:00+03:00")
+
+		result, err = leap.AddYears(1, Normalize)
+		So(err, ShouldBeNil)
+		So(result.String(), ShouldEqual, "2017-03-01T12:00:00+03:00")
+
+		_, err = leap.AddYears(1, Strict)
+		So(err, ShouldNotBeNil)
+		result, err = leap.AddYears(4, Strict)
+		So(err, ShouldBeNil)
+		So(result.String(), ShouldEqual, "2020-02-29T12:00:00+03:00")
+
+		result, err = leap.AddDate(1, 1, 1)
+		So(err, ShouldBeNil)
+		So(result.String(), ShouldEqual, "2017-03-30T12:00:00+03:00")
+
+		So(leap.AddDays(-60).String(), ShouldEqual, "2015-12-31T12:00:00+03:00")
+	})
+	Convey("День перехода на летнее время считается одним днём", t, func() {
+		location, err := time.LoadLocation("Europe/Berlin")
+		So(err, ShouldBeNil)
+		value := Time(time.Date(2018, 3, 24, 12, 0, 0, 0, location))
+		So(value.AddDays(1).String(), ShouldEqual, "2018-03-25T12:00:00+02:00")
+		So(value.AddDays(1).Time().Sub(value.Time()), ShouldEqual, 23*time.Hour)
+
+		result, err := value.AddMonths(1)
+		So(err, ShouldBeNil)
+		So(result.String(), ShouldEqual, "2018-04-24T12:00:00+02:00")
+	})
+}
+
+func testAddMonths(value Time, months int, policy []DatePolicy, expected string) {
+	result, err := value.AddMonths(months, policy...)
+	So(err, ShouldBeNil)
+	So(result.String(), ShouldEqual, expected)
+}
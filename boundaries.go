@@ -0,0 +1,121 @@
+package times
+
+import (
+	"time"
+)
+
+// Границы календарных периодов вычисляются по часам часового пояса метки времени.
+// Начало периода это первый момент периода, конец - последняя наносекунда перед началом следующего.
+// Если полночь пропущена из-за перехода на летнее время, началом дня является первый момент после перехода
+
+// StartOfHour возвращает начало часа
+func (t Time) StartOfHour() Time {
+	value := t.Time()
+	return Time(value.Add(-time.Duration(value.Minute())*time.Minute -
+		time.Duration(value.Second())*time.Second -
+		time.Duration(value.Nanosecond())))
+}
+
+// EndOfHour возвращает конец часа
+func (t Time) EndOfHour() Time {
+	return Time(t.StartOfHour().Time().Add(time.Hour - time.Nanosecond))
+}
+
+// StartOfDay возвращает начало дня
+func (t Time) StartOfDay() Time {
+	year, month, day := t.Time().Date()
+	return t.startOf(year, month, day)
+}
+
+// EndOfDay возвращает конец дня
+func (t Time) EndOfDay() Time {
+	year, month, day := t.Time().Date()
+	return t.endBefore(year, month, day+1)
+}
+
+// StartOfWeek возвращает начало недели
+// Необязательный weekStart задаёт первый день недели, по умолчанию понедельник
+func (t Time) StartOfWeek(weekStart ...time.Weekday) Time {
+	year, month, day := t.Time().Date()
+	return t.startOf(year, month, day-t.weekOffset(weekStart))
+}
+
+// EndOfWeek возвращает конец недели
+// Необязательный weekStart задаёт первый день недели, по умолчанию понедельник
+func (t Time) EndOfWeek(weekStart ...time.Weekday) Time {
+	year, month, day := t.Time().Date()
+	return t.endBefore(year, month, day-t.weekOffset(weekStart)+7)
+}
+
+// weekOffset возвращает количество дней от начала недели
+func (t Time) weekOffset(weekStart []time.Weekday) int {
+	first := time.Monday
+	if len(weekStart) > 0 {
+		first = weekStart[0]
+	}
+	return (int(t.Time().Weekday()) - int(first) + 7) % 7
+}
+
+// StartOfMonth возвращает начало месяца
+func (t Time) StartOfMonth() Time {
+	year, month, _ := t.Time().Date()
+	return t.startOf(year, month, 1)
+}
+
+// EndOfMonth возвращает конец месяца
+func (t Time) EndOfMonth() Time {
+	year, month, _ := t.Time().Date()
+	return t.endBefore(year, month+1, 1)
+}
+
+// StartOfQuarter возвращает начало квартала
+func (t Time) StartOfQuarter() Time {
+	year, month, _ := t.Time().Date()
+	return t.startOf(year, quarterMonth(month), 1)
+}
+
+// EndOfQuarter возвращает конец квартала
+func (t Time) EndOfQuarter() Time {
+	year, month, _ := t.Time().Date()
+	return t.endBefore(year, quarterMonth(month)+3, 1)
+}
+
+// quarterMonth возвращает первый месяц квартала
+func quarterMonth(month time.Month) time.Month {
+	return month - (month-1)%3
+}
+
+// StartOfYear возвращает начало года
+func (t Time) StartOfYear() Time {
+	return t.startOf(t.Time().Year(), time.January, 1)
+}
+
+// EndOfYear возвращает конец года
+func (t Time) EndOfYear() Time {
+	return t.endBefore(t.Time().Year()+1, time.January, 1)
+}
+
+// startOf возвращает начало дня в часовом поясе метки времени
+// Если полночь пропущена, выбирается момент перехода,
+// если повторяется из-за перехода на зимнее время - первая из них
+func (t Time) startOf(year int, month time.Month, day int) Time {
+	result := time.Date(year, month, day, 0, 0, 0, 0, t.Time().Location())
+	_, offset := result.Zone()
+	if result.Hour() != 0 || result.Minute() != 0 {
+		_, after := result.Add(12 * time.Hour).Zone()
+		return Time(result.Add(time.Duration(after-offset) * time.Second))
+	}
+	_, before := result.Add(-12 * time.Hour).Zone()
+	if before > offset {
+		earlier := result.Add(-time.Duration(before-offset) * time.Second)
+		if earlier.Day() == result.Day() && earlier.Hour() == 0 && earlier.Minute() == 0 {
+			result = earlier
+		}
+	}
+	return Time(result)
+}
+
+// endBefore возвращает последнюю наносекунду перед началом дня
+func (t Time) endBefore(year int, month time.Month, day int) Time {
+	return Time(t.startOf(year, month, day).Time().Add(-time.Nanosecond))
+}
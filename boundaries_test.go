@@ -0,0 +1,59 @@
+package times
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBoundaries(t *testing.T) {
+	Convey("Проверяем границы периодов", t, func() {
+		value := Time(time.Date(2018, 8, 15, 13, 45, 30, 500, MoscowLocation))
+
+		So(value.StartOfHour().String(), ShouldEqual, "2018-08-15T13:00:00+03:00")
+		So(value.EndOfHour().Time().Format(time.RFC3339Nano), ShouldEqual, "2018-08-15T13:59:59.999999999+03:00")
+		So(value.StartOfDay().String(), ShouldEqual, "2018-08-15T00:00:00+03:00")
+		So(value.EndOfDay().Time().Format(time.RFC3339Nano), ShouldEqual, "2018-08-15T23:59:59.999999999+03:00")
+		So(value.StartOfWeek().String(), ShouldEqual, "2018-08-13T00:00:00+03:00")
+		So(value.StartOfWeek(time.Sunday).String(), ShouldEqual, "2018-08-12T00:00:00+03:00")
+		So(value.EndOfWeek().String(), ShouldEqual, "2018-08-19T23:59:59+03:00")
+		So(value.EndOfWeek(time.Sunday).String(), ShouldEqual, "2018-08-18T23:59:59+03:00")
+		So(value.StartOfMonth().String(), ShouldEqual, "2018-08-01T00:00:00+03:00")
+		So(value.EndOfMonth().String(), ShouldEqual, "2018-08-31T23:59:59+03:00")
+		So(value.StartOfQuarter().String(), ShouldEqual, "2018-07-01T00:00:00+03:00")
+		So(value.EndOfQuarter().String(), ShouldEqual, "2018-09-30T23:59:59+03:00")
+		So(value.StartOfYear().String(), ShouldEqual, "2018-01-01T00:00:00+03:00")
+		So(value.EndOfYear().String(), ShouldEqual, "2018-12-31T23:59:59+03:00")
+
+		sunday := Time(time.Date(2018, 8, 19, 10, 0, 0, 0, MoscowLocation))
+		So(sunday.StartOfWeek().String(), ShouldEqual, "2018-08-13T00:00:00+03:00")
+		So(sunday.StartOfWeek(time.Sunday).String(), ShouldEqual, "2018-08-19T00:00:00+03:00")
+
+		february := Time(time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC))
+		So(february.EndOfMonth().String(), ShouldEqual, "2020-02-29T23:59:59Z")
+		So(february.StartOfQuarter().String(), ShouldEqual, "2020-01-01T00:00:00Z")
+	})
+	Convey("Проверяем границы при переходе на летнее и зимнее время", t, func() {
+		Convey("Пропущенная полночь", func() {
+			location, err := time.LoadLocation("America/Sao_Paulo")
+			So(err, ShouldBeNil)
+			value := Time(time.Date(2018, 11, 4, 12, 0, 0, 0, location))
+			So(value.StartOfDay().String(), ShouldEqual, "2018-11-04T01:00:00-02:00")
+			So(Time(time.Date(2018, 11, 3, 12, 0, 0, 0, location)).EndOfDay().String(), ShouldEqual, "2018-11-03T23:59:59-03:00")
+		})
+		Convey("Повторённая полночь", func() {
+			location, err := time.LoadLocation("America/Havana")
+			So(err, ShouldBeNil)
+			value := Time(time.Date(2018, 11, 4, 12, 0, 0, 0, location))
+			So(value.StartOfDay().String(), ShouldEqual, "2018-11-04T00:00:00-04:00")
+			So(value.EndOfDay().String(), ShouldEqual, "2018-11-04T23:59:59-05:00")
+		})
+		Convey("Продолжительность дня", func() {
+			location, err := time.LoadLocation("Europe/Berlin")
+			So(err, ShouldBeNil)
+			value := Time(time.Date(2018, 3, 25, 12, 0, 0, 0, location))
+			So(value.EndOfDay().Time().Sub(value.StartOfDay().Time()), ShouldEqual, 23*time.Hour-time.Nanosecond)
+		})
+	})
+}
@@ -0,0 +1,107 @@
+package times
+
+import (
+	"time"
+)
+
+// Calendar это производственный календарь
+// Реализация для Российской Федерации находится в пакете times/workdays
+type Calendar interface {
+	// IsWorkday возвращает true если дата является рабочим днём
+	IsWorkday(date Date) bool
+}
+
+// BoundedCalendar это календарь, содержащий данные только за некоторые годы
+// Для дат вне этих лет функции рабочих дней возвращают *CalendarRangeError
+type BoundedCalendar interface {
+	Calendar
+
+	// Covers возвращает true если календарь содержит данные за год
+	Covers(year int) bool
+}
+
+// checkCalendar возвращает *CalendarRangeError если календарь не содержит данных за год даты
+func checkCalendar(calendar Calendar, date Date) error {
+	if bounded, ok := calendar.(BoundedCalendar); ok && !bounded.Covers(date.Year) {
+		return &CalendarRangeError{
+			Year: date.Year,
+		}
+	}
+	return nil
+}
+
+// IsWorkday возвращает true если дата метки времени является рабочим днём
+func (t Time) IsWorkday(calendar Calendar) (bool, error) {
+	date := t.Date()
+	err := checkCalendar(calendar, date)
+	if err != nil {
+		return false, err
+	}
+	return calendar.IsWorkday(date), nil
+}
+
+// AddWorkdays возвращает метку времени через n рабочих дней, время суток сохраняется
+// Текущий день не учитывается, отрицательное n сдвигает назад
+// Пример:
+//   пятница + 1 рабочий день = понедельник
+//   суббота + 1 рабочий день = понедельник
+func (t Time) AddWorkdays(calendar Calendar, n int) (Time, error) {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	result := t.Time()
+	for n > 0 {
+		result = result.AddDate(0, 0, step)
+		date := DateOf(result)
+		err := checkCalendar(calendar, date)
+		if err != nil {
+			return Time{}, err
+		}
+		if calendar.IsWorkday(date) {
+			n--
+		}
+	}
+	return Time(result), nil
+}
+
+// WorkdaysBetween возвращает количество рабочих дней от даты t включительно
+// до даты end не включительно, end приводится к часовому поясу t
+// Если end раньше t, результат отрицательный
+func (t Time) WorkdaysBetween(calendar Calendar, end Time) (int, error) {
+	start := t.Date()
+	finish := DateOf(end.Time().In(t.Time().Location()))
+	sign := 1
+	if finish.Before(start) {
+		start, finish, sign = finish, start, -1
+	}
+	result := 0
+	for d := start; d.Before(finish); d = d.AddDays(1) {
+		err := checkCalendar(calendar, d)
+		if err != nil {
+			return 0, err
+		}
+		if calendar.IsWorkday(d) {
+			result++
+		}
+	}
+	return sign * result, nil
+}
+
+// UntilEndMonthWorkdays возвращает количество рабочих дней
+// от текущей даты включительно до конца текущего месяца
+func (t Time) UntilEndMonthWorkdays(calendar Calendar) (int, error) {
+	return t.Until(EndOfMonth, 0).Workdays(calendar)
+}
+
+// weekends это календарь без праздников с выходными в субботу и воскресенье
+type weekends struct{}
+
+// IsWorkday возвращает true для дней с понедельника по пятницу
+func (weekends) IsWorkday(date Date) bool {
+	weekday := date.Weekday()
+	return weekday != time.Saturday && weekday != time.Sunday
+}
+
+// Weekends это календарь без праздников с выходными в субботу и воскресенье
+var Weekends Calendar = weekends{}
@@ -0,0 +1,98 @@
+package times
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testHolidays это календарь с выходными в субботу, воскресенье и на 8 марта
+type testHolidays struct{}
+
+func (testHolidays) IsWorkday(date Date) bool {
+	if date.Month == time.March && date.Day == 8 {
+		return false
+	}
+	return Weekends.IsWorkday(date)
+}
+
+// testBounded это календарь с данными только за 2018 год
+type testBounded struct {
+	testHolidays
+}
+
+func (testBounded) Covers(year int) bool {
+	return year == 2018
+}
+
+func TestCalendar(t *testing.T) {
+	Convey("Проверяем рабочие дни", t, func() {
+		friday := Time(time.Date(2018, 3, 2, 18, 30, 0, 0, MoscowLocation))
+		saturday := Time(time.Date(2018, 3, 3, 18, 30, 0, 0, MoscowLocation))
+
+		So(testIsWorkday(friday, Weekends), ShouldBeTrue)
+		So(testIsWorkday(saturday, Weekends), ShouldBeFalse)
+
+		So(testAddWorkdays(friday, Weekends, 1), ShouldEqual, "2018-03-05T18:30:00+03:00")
+		So(testAddWorkdays(saturday, Weekends, 1), ShouldEqual, "2018-03-05T18:30:00+03:00")
+		So(testAddWorkdays(friday, Weekends, 5), ShouldEqual, "2018-03-09T18:30:00+03:00")
+		So(testAddWorkdays(friday, testHolidays{}, 5), ShouldEqual, "2018-03-12T18:30:00+03:00")
+		So(testAddWorkdays(friday, Weekends, -5), ShouldEqual, "2018-02-23T18:30:00+03:00")
+		So(testAddWorkdays(friday, Weekends, 0), ShouldEqual, friday.String())
+	})
+	Convey("Проверяем количество рабочих дней", t, func() {
+		start := Time(time.Date(2018, 3, 1, 0, 0, 0, 0, MoscowLocation))
+		end := Time(time.Date(2018, 4, 1, 0, 0, 0, 0, MoscowLocation))
+
+		So(testWorkdaysBetween(start, Weekends, end), ShouldEqual, 22)
+		So(testWorkdaysBetween(start, testHolidays{}, end), ShouldEqual, 21)
+		So(testWorkdaysBetween(end, testHolidays{}, start), ShouldEqual, -21)
+		So(testWorkdaysBetween(start, Weekends, start), ShouldEqual, 0)
+
+		n, err := start.UntilEndMonthWorkdays(testHolidays{})
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, 21)
+		last := Time(time.Date(2018, 3, 30, 23, 0, 0, 0, MoscowLocation))
+		n, err = last.UntilEndMonthWorkdays(Weekends)
+		So(err, ShouldBeNil)
+		So(n, ShouldEqual, 1)
+	})
+	Convey("Проверяем календарь с данными не за все годы", t, func() {
+		start := Time(time.Date(2018, 12, 28, 10, 0, 0, 0, MoscowLocation))
+		So(testIsWorkday(start, testBounded{}), ShouldBeTrue)
+		So(testAddWorkdays(start, testBounded{}, 1), ShouldEqual, "2018-12-31T10:00:00+03:00")
+
+		_, err := start.AddWorkdays(testBounded{}, 2)
+		var rangeErr *CalendarRangeError
+		So(errors.As(err, &rangeErr), ShouldBeTrue)
+		So(rangeErr.Year, ShouldEqual, 2019)
+		So(err.Error(), ShouldEqual, "times: calendar has no data for year 2019")
+
+		_, err = start.WorkdaysBetween(testBounded{}, Time(time.Date(2019, 1, 2, 0, 0, 0, 0, MoscowLocation)))
+		So(err, ShouldNotBeNil)
+		_, err = Time(time.Date(2019, 1, 2, 0, 0, 0, 0, MoscowLocation)).IsWorkday(testBounded{})
+		So(err, ShouldNotBeNil)
+		_, err = Time(time.Date(2019, 1, 2, 0, 0, 0, 0, MoscowLocation)).UntilEndMonthWorkdays(testBounded{})
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func testIsWorkday(t Time, calendar Calendar) bool {
+	result, err := t.IsWorkday(calendar)
+	So(err, ShouldBeNil)
+	return result
+}
+
+func testAddWorkdays(t Time, calendar Calendar, n int) string {
+	result, err := t.AddWorkdays(calendar, n)
+	So(err, ShouldBeNil)
+	return result.String()
+}
+
+func testWorkdaysBetween(t Time, calendar Calendar, end Time) int {
+	result, err := t.WorkdaysBetween(calendar, end)
+	So(err, ShouldBeNil)
+	return result
+}
@@ -0,0 +1,143 @@
+package times
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Clock это источник текущего времени и таймеров
+// В тестах вместо SystemClock используется FakeClock
+type Clock interface {
+	// Now возвращает текущее время
+	Now() time.Time
+
+	// After возвращает канал, в который придёт время через d
+	After(d time.Duration) <-chan time.Time
+
+	// Sleep приостанавливает выполнение на d
+	Sleep(d time.Duration)
+
+	// NewTimer возвращает таймер, срабатывающий через d
+	NewTimer(d time.Duration) Timer
+
+	// NewTicker возвращает тикер с периодом d
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer это таймер, см. time.Timer
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// Ticker это тикер, см. time.Ticker
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+	Reset(d time.Duration)
+}
+
+// SystemClock это системные часы на основе пакета time
+var SystemClock Clock = systemClock{}
+
+// systemClock это системные часы
+type systemClock struct{}
+
+// Now возвращает текущее время
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After возвращает канал, в который придёт время через d
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Sleep приостанавливает выполнение на d
+func (systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// NewTimer возвращает таймер, срабатывающий через d
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+// NewTicker возвращает тикер с периодом d
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+// systemTimer это таймер пакета time
+type systemTimer struct {
+	*time.Timer
+}
+
+// C возвращает канал таймера
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// systemTicker это тикер пакета time
+type systemTicker struct {
+	*time.Ticker
+}
+
+// C возвращает канал тикера
+func (t systemTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// clockHolder позволяет хранить интерфейс в atomic.Value
+type clockHolder struct {
+	clock Clock
+}
+
+var defaultClock atomic.Value
+
+func init() {
+	defaultClock.Store(clockHolder{SystemClock})
+}
+
+// DefaultClock возвращает часы по умолчанию
+func DefaultClock() Clock {
+	return defaultClock.Load().(clockHolder).clock
+}
+
+// SetDefaultClock устанавливает часы по умолчанию и возвращает предыдущие
+// nil означает SystemClock
+// Пример:
+//   defer times.SetDefaultClock(times.SetDefaultClock(fake))
+func SetDefaultClock(clock Clock) Clock {
+	if clock == nil {
+		clock = SystemClock
+	}
+	previous := DefaultClock()
+	defaultClock.Store(clockHolder{clock})
+	return previous
+}
+
+// clockKey это ключ часов в context.Context
+type clockKey struct{}
+
+// WithClock возвращает контекст с часами
+func WithClock(ctx context.Context, clock Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, clock)
+}
+
+// ClockFrom возвращает часы из контекста или часы по умолчанию
+func ClockFrom(ctx context.Context) Clock {
+	if ctx != nil {
+		if clock, ok := ctx.Value(clockKey{}).(Clock); ok && clock != nil {
+			return clock
+		}
+	}
+	return DefaultClock()
+}
+
+// Now возвращает текущее время в UTC по часам из контекста
+func Now(ctx context.Context) Time {
+	return Time(ClockFrom(ctx).Now().In(time.UTC))
+}
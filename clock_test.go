@@ -0,0 +1,54 @@
+package times
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestClock(t *testing.T) {
+	Convey("Проверяем часы по умолчанию", t, func() {
+		So(DefaultClock(), ShouldEqual, SystemClock)
+
+		fake := NewFakeClock(time.Date(2018, 1, 31, 23, 59, 0, 0, MoscowLocation))
+		previous := SetDefaultClock(fake)
+		So(previous, ShouldEqual, SystemClock)
+
+		current, err := NewCurrentTime()
+		So(err, ShouldBeNil)
+		So(current.String(), ShouldEqual, "2018-01-31T20:59:00Z")
+		So(Now(context.Background()).String(), ShouldEqual, "2018-01-31T20:59:00Z")
+		So(Now(nil).String(), ShouldEqual, "2018-01-31T20:59:00Z")
+
+		So(SetDefaultClock(nil), ShouldEqual, fake)
+		So(DefaultClock(), ShouldEqual, SystemClock)
+	})
+	Convey("Проверяем часы в контексте", t, func() {
+		fake := NewFakeClock(time.Date(2018, 1, 31, 0, 0, 0, 0, time.UTC))
+		ctx := WithClock(context.Background(), fake)
+		So(ClockFrom(ctx), ShouldEqual, fake)
+		So(Now(ctx).String(), ShouldEqual, "2018-01-31T00:00:00Z")
+		So(ClockFrom(context.Background()), ShouldEqual, SystemClock)
+
+		fake.Advance(24 * time.Hour)
+		So(Now(ctx).UntilEndMonthDays(), ShouldEqual, 28)
+	})
+	Convey("Проверяем системные часы", t, func() {
+		before := time.Now()
+		So(SystemClock.Now().Before(before), ShouldBeFalse)
+
+		timer := SystemClock.NewTimer(time.Millisecond)
+		<-timer.C()
+		So(timer.Stop(), ShouldBeFalse)
+
+		ticker := SystemClock.NewTicker(time.Millisecond)
+		<-ticker.C()
+		ticker.Stop()
+
+		<-SystemClock.After(time.Millisecond)
+		SystemClock.Sleep(time.Millisecond)
+		So(time.Since(before), ShouldBeGreaterThan, 3*time.Millisecond)
+	})
+}
@@ -0,0 +1,345 @@
+// Package cron разбирает выражения cron и вычисляет время срабатывания как times.Time
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mantyr/times"
+)
+
+// searchYears это количество лет, после которого поиск прекращается
+// Григорианский календарь повторяется каждые 400 лет,
+// поэтому если срабатываний нет за 400 лет, их нет совсем
+const searchYears = 400
+
+var macros = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// Schedule это разобранное выражение cron
+//
+// Поддерживаются выражения из 5 полей (минута, час, день месяца, месяц, день недели),
+// 6 полей (с секундами в начале) и 7 полей (с годом в конце), например:
+//   «0 9 * * MON-FRI»      - «в 9:00 по рабочим дням»
+//   «0 0 12 L * ?»         - «в 12:00 в последний день месяца»
+//   «0 30 10 15W * ? 2019» - «в 10:30 в ближайший к 15 числу рабочий день 2019 года»
+//
+// а также макросы @yearly, @annually, @monthly, @weekly, @daily, @midnight и @hourly.
+// Префикс CRON_TZ=<зона> или TZ=<зона> задаёт часовой пояс выражения.
+//
+// Если ограничены и день месяца, и день недели, подходит любой из них, как в Vixie cron.
+//
+// Время срабатывания вычисляется по часам часового пояса выражения.
+// Время внутри перехода на летнее время срабатывает в момент перехода,
+// неоднозначное время при переходе на зимнее время срабатывает один раз, в первый из моментов
+type Schedule struct {
+	expr     string
+	location *time.Location
+	seconds  bits
+	minutes  bits
+	hours    bits
+	days     dayField
+	months   bits
+	weekdays weekdayField
+	years    []int
+}
+
+// Parse разбирает выражение cron, время вычисляется в location
+func Parse(expr string, location *time.Location) (*Schedule, error) {
+	if location == nil {
+		return nil, errors.New("cron: empty time location")
+	}
+	s := &Schedule{
+		expr:     expr,
+		location: location,
+	}
+	spec := strings.TrimSpace(expr)
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		i := strings.IndexAny(spec, " \t")
+		if i < 0 {
+			return nil, fmt.Errorf("cron: missing fields in %q", expr)
+		}
+		name := spec[strings.IndexByte(spec, '=')+1 : i]
+		var err error
+		s.location, err = times.LoadLocation(name)
+		if err != nil {
+			return nil, err
+		}
+		spec = strings.TrimSpace(spec[i:])
+	}
+	if strings.HasPrefix(spec, "@") {
+		macro, ok := macros[strings.ToLower(spec)]
+		if !ok {
+			return nil, fmt.Errorf("cron: unknown macro %q", spec)
+		}
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6, 7:
+	default:
+		return nil, fmt.Errorf("cron: expected 5, 6 or 7 fields but actual %d in %q", len(fields), expr)
+	}
+	var err error
+	if s.seconds, err = parseBits(fields[0], secondBounds); err != nil {
+		return nil, err
+	}
+	if s.minutes, err = parseBits(fields[1], minuteBounds); err != nil {
+		return nil, err
+	}
+	if s.hours, err = parseBits(fields[2], hourBounds); err != nil {
+		return nil, err
+	}
+	if s.days, err = parseDayField(fields[3]); err != nil {
+		return nil, err
+	}
+	if s.months, err = parseBits(fields[4], monthBounds); err != nil {
+		return nil, err
+	}
+	if s.weekdays, err = parseWeekdayField(fields[5]); err != nil {
+		return nil, err
+	}
+	if len(fields) == 7 {
+		if s.years, err = parseYears(fields[6]); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// MustParse разбирает выражение cron и паникует при ошибке
+func MustParse(expr string, location *time.Location) *Schedule {
+	s, err := Parse(expr, location)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// String возвращает исходное выражение
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Location возвращает часовой пояс выражения
+func (s *Schedule) Location() *time.Location {
+	return s.location
+}
+
+// Next возвращает первое срабатывание строго после t
+// Второе значение false если срабатываний больше нет
+func (s *Schedule) Next(t times.Time) (times.Time, bool) {
+	after := t.Time()
+	wall := clock(after.In(s.location))
+	limit := wall.Year() + searchYears
+	if len(s.years) > 0 {
+		limit = s.years[len(s.years)-1]
+	}
+	for {
+		var ok bool
+		wall, ok = s.nextWall(wall, limit)
+		if !ok {
+			return times.Time{}, false
+		}
+		result := resolve(wall, s.location)
+		if result.After(after) {
+			return times.Time(result), true
+		}
+		wall = wall.Add(time.Second)
+	}
+}
+
+// Prev возвращает последнее срабатывание строго до t
+// Второе значение false если срабатываний не было
+func (s *Schedule) Prev(t times.Time) (times.Time, bool) {
+	before := t.Time()
+	// При переходе на зимнее время более позднее время на часах
+	// может соответствовать более раннему моменту, поэтому поиск начинается с запасом
+	wall := clock(before.In(s.location)).Add(3 * time.Hour)
+	limit := wall.Year() - searchYears
+	if len(s.years) > 0 {
+		limit = s.years[0]
+	}
+	for {
+		var ok bool
+		wall, ok = s.prevWall(wall, limit)
+		if !ok {
+			return times.Time{}, false
+		}
+		result := resolve(wall, s.location)
+		if result.Before(before) {
+			return times.Time(result), true
+		}
+		wall = wall.Add(-time.Second)
+	}
+}
+
+// NextN возвращает не более n следующих срабатываний после t
+func (s *Schedule) NextN(t times.Time, n int) []times.Time {
+	result := make([]times.Time, 0, n)
+	for len(result) < n {
+		next, ok := s.Next(t)
+		if !ok {
+			break
+		}
+		result = append(result, next)
+		t = next
+	}
+	return result
+}
+
+// nextWall возвращает первое подходящее время на часах не раньше wall
+// Время на часах хранится в UTC
+func (s *Schedule) nextWall(wall time.Time, limit int) (time.Time, bool) {
+	wall = wall.Truncate(time.Second)
+	for wall.Year() <= limit {
+		year, month, day := wall.Date()
+		switch {
+		case !s.matchYear(year):
+			next := s.nextYear(year)
+			if next < 0 {
+				return time.Time{}, false
+			}
+			wall = time.Date(next, time.January, 1, 0, 0, 0, 0, time.UTC)
+		case !s.months.has(int(month)):
+			wall = time.Date(year, month+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.matchDay(wall):
+			wall = time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+		case !s.hours.has(wall.Hour()):
+			wall = wall.Truncate(time.Hour).Add(time.Hour)
+		case !s.minutes.has(wall.Minute()):
+			wall = wall.Truncate(time.Minute).Add(time.Minute)
+		case !s.seconds.has(wall.Second()):
+			wall = wall.Add(time.Second)
+		default:
+			return wall, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// prevWall возвращает последнее подходящее время на часах не позже wall
+func (s *Schedule) prevWall(wall time.Time, limit int) (time.Time, bool) {
+	wall = wall.Truncate(time.Second)
+	for wall.Year() >= limit && wall.Year() > 0 {
+		year, month, day := wall.Date()
+		switch {
+		case !s.matchYear(year):
+			prev := s.prevYear(year)
+			if prev < 0 {
+				return time.Time{}, false
+			}
+			wall = time.Date(prev+1, time.January, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+		case !s.months.has(int(month)):
+			wall = time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+		case !s.matchDay(wall):
+			wall = time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+		case !s.hours.has(wall.Hour()):
+			wall = wall.Truncate(time.Hour).Add(-time.Second)
+		case !s.minutes.has(wall.Minute()):
+			wall = wall.Truncate(time.Minute).Add(-time.Second)
+		case !s.seconds.has(wall.Second()):
+			wall = wall.Add(-time.Second)
+		default:
+			return wall, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// matchYear возвращает true если год подходит выражению
+func (s *Schedule) matchYear(year int) bool {
+	if len(s.years) == 0 {
+		return true
+	}
+	i := sort.SearchInts(s.years, year)
+	return i < len(s.years) && s.years[i] == year
+}
+
+// nextYear возвращает первый подходящий год после year или -1
+func (s *Schedule) nextYear(year int) int {
+	i := sort.SearchInts(s.years, year+1)
+	if i == len(s.years) {
+		return -1
+	}
+	return s.years[i]
+}
+
+// prevYear возвращает последний подходящий год до year или -1
+func (s *Schedule) prevYear(year int) int {
+	i := sort.SearchInts(s.years, year)
+	if i == 0 {
+		return -1
+	}
+	return s.years[i-1]
+}
+
+// matchDay возвращает true если день подходит выражению
+func (s *Schedule) matchDay(d time.Time) bool {
+	switch {
+	case s.days.star && s.weekdays.star:
+		return true
+	case s.days.star:
+		return s.weekdays.match(d)
+	case s.weekdays.star:
+		return s.days.match(d)
+	}
+	return s.days.match(d) || s.weekdays.match(d)
+}
+
+// clock возвращает показания часов t как время в UTC
+func clock(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// resolve возвращает момент, которому соответствует время на часах в location
+//
+// Для неоднозначного времени выбирается первый из моментов,
+// для времени внутри перехода на летнее время - момент перехода.
+// Так более позднее время на часах никогда не даёт более ранний момент
+func resolve(wall time.Time, location *time.Location) time.Time {
+	_, before := wall.Add(-12 * time.Hour).In(location).Zone()
+	_, after := wall.Add(12 * time.Hour).In(location).Zone()
+	var result time.Time
+	for _, offset := range []int{before, after} {
+		t := wall.Add(-time.Duration(offset) * time.Second).In(location)
+		if !clock(t).Equal(wall) {
+			continue
+		}
+		if result.IsZero() || t.Before(result) {
+			result = t
+		}
+	}
+	if !result.IsZero() {
+		return result
+	}
+	// Время внутри перехода: ищем момент перехода между двумя смещениями
+	low := wall.Add(-time.Duration(after) * time.Second)
+	high := wall.Add(-time.Duration(before) * time.Second)
+	if high.Before(low) {
+		low, high = high, low
+	}
+	for high.Sub(low) > time.Second {
+		middle := low.Add(high.Sub(low) / 2).Truncate(time.Second)
+		if _, offset := middle.In(location).Zone(); offset == before {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+	return high.In(location)
+}
@@ -0,0 +1,206 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mantyr/times"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSchedule(t *testing.T) {
+	Convey("Проверяем следующие срабатывания", t, func() {
+		testNext(
+			"0 9 * * MON-FRI",
+			"2018-01-05T10:00:00+03:00",
+			"2018-01-08T09:00:00+03:00",
+			"2018-01-09T09:00:00+03:00",
+			"2018-01-10T09:00:00+03:00",
+		)
+		testNext(
+			"*/20 9-10 * * *",
+			"2018-01-01T09:30:00+03:00",
+			"2018-01-01T09:40:00+03:00",
+			"2018-01-01T10:00:00+03:00",
+			"2018-01-01T10:20:00+03:00",
+			"2018-01-01T10:40:00+03:00",
+			"2018-01-02T09:00:00+03:00",
+		)
+		testNext(
+			"30 */15 * * * *",
+			"2018-01-01T00:00:30+03:00",
+			"2018-01-01T00:15:30+03:00",
+			"2018-01-01T00:30:30+03:00",
+		)
+		testNext(
+			"0 0 12 L * ?",
+			"2018-01-31T12:00:00+03:00",
+			"2018-02-28T12:00:00+03:00",
+			"2018-03-31T12:00:00+03:00",
+		)
+		testNext(
+			"0 0 L-1 * *",
+			"2018-01-01T00:00:00+03:00",
+			"2018-01-30T00:00:00+03:00",
+			"2018-02-27T00:00:00+03:00",
+		)
+		testNext(
+			"0 0 15W * *",
+			"2018-08-01T00:00:00+03:00",
+			"2018-08-15T00:00:00+03:00",
+			"2018-09-14T00:00:00+03:00",
+			"2018-10-15T00:00:00+03:00",
+		)
+		testNext(
+			"0 0 1W,LW * *",
+			"2018-09-01T00:00:00+03:00",
+			"2018-09-03T00:00:00+03:00",
+			"2018-09-28T00:00:00+03:00",
+		)
+		testNext(
+			"0 0 * * FRI#3",
+			"2018-01-01T00:00:00+03:00",
+			"2018-01-19T00:00:00+03:00",
+			"2018-02-16T00:00:00+03:00",
+		)
+		testNext(
+			"0 0 * * 5L",
+			"2018-01-01T00:00:00+03:00",
+			"2018-01-26T00:00:00+03:00",
+			"2018-02-23T00:00:00+03:00",
+		)
+		testNext(
+			"0 0 13 * 5",
+			"2018-04-01T00:00:00+03:00",
+			"2018-04-06T00:00:00+03:00",
+			"2018-04-13T00:00:00+03:00",
+			"2018-04-20T00:00:00+03:00",
+		)
+		testNext(
+			"0 0 0 29 2 ? 2020-2030",
+			"2018-01-01T00:00:00+03:00",
+			"2020-02-29T00:00:00+03:00",
+			"2024-02-29T00:00:00+03:00",
+			"2028-02-29T00:00:00+03:00",
+		)
+		testNext(
+			"@weekly",
+			"2018-01-01T00:00:00+03:00",
+			"2018-01-07T00:00:00+03:00",
+			"2018-01-14T00:00:00+03:00",
+		)
+		testNext(
+			"CRON_TZ=UTC @daily",
+			"2018-01-01T00:00:00+03:00",
+			"2018-01-01T00:00:00Z",
+			"2018-01-02T00:00:00Z",
+		)
+		testNext("0 0 30 2 *", "2018-01-01T00:00:00+03:00")
+		testNext("0 0 0 1 1 ? 2017", "2018-01-01T00:00:00+03:00")
+	})
+	Convey("Проверяем предыдущие срабатывания", t, func() {
+		s := MustParse("0 9 * * MON-FRI", times.MoscowLocation)
+		prev, ok := s.Prev(testTime("2018-01-08T09:00:00+03:00"))
+		So(ok, ShouldBeTrue)
+		So(prev.String(), ShouldEqual, "2018-01-05T09:00:00+03:00")
+
+		s = MustParse("0 0 0 1 1 ? 2019", times.MoscowLocation)
+		_, ok = s.Prev(testTime("2018-06-01T00:00:00+03:00"))
+		So(ok, ShouldBeFalse)
+		prev, ok = s.Prev(testTime("2020-06-01T00:00:00+03:00"))
+		So(ok, ShouldBeTrue)
+		So(prev.String(), ShouldEqual, "2019-01-01T00:00:00+03:00")
+	})
+	Convey("Проверяем переход на летнее и зимнее время", t, func() {
+		berlin, err := time.LoadLocation("Europe/Berlin")
+		So(err, ShouldBeNil)
+		Convey("Время внутри перехода срабатывает в момент перехода", func() {
+			s := MustParse("30 2 * * *", berlin)
+			So(testStrings(s.NextN(testTime("2018-03-24T12:00:00+01:00"), 3)), ShouldResemble, []string{
+				"2018-03-25T03:00:00+02:00",
+				"2018-03-26T02:30:00+02:00",
+				"2018-03-27T02:30:00+02:00",
+			})
+			s = MustParse("*/30 2-3 25 3 *", berlin)
+			So(testStrings(s.NextN(testTime("2018-03-25T00:00:00+01:00"), 3)), ShouldResemble, []string{
+				"2018-03-25T03:00:00+02:00",
+				"2018-03-25T03:30:00+02:00",
+				"2019-03-25T02:00:00+01:00",
+			})
+		})
+		Convey("Неоднозначное время срабатывает один раз", func() {
+			s := MustParse("30 2 * * *", berlin)
+			So(testStrings(s.NextN(testTime("2018-10-27T12:00:00+02:00"), 3)), ShouldResemble, []string{
+				"2018-10-28T02:30:00+02:00",
+				"2018-10-29T02:30:00+01:00",
+				"2018-10-30T02:30:00+01:00",
+			})
+			s = MustParse("0 * 28 10 *", berlin)
+			So(testStrings(s.NextN(testTime("2018-10-28T01:30:00+02:00"), 3)), ShouldResemble, []string{
+				"2018-10-28T02:00:00+02:00",
+				"2018-10-28T03:00:00+01:00",
+				"2018-10-28T04:00:00+01:00",
+			})
+			prev, ok := s.Prev(testTime("2018-10-28T03:00:00+01:00"))
+			So(ok, ShouldBeTrue)
+			So(prev.Time().Format(time.RFC3339), ShouldEqual, "2018-10-28T02:00:00+02:00")
+		})
+	})
+	Convey("Проверяем ошибки", t, func() {
+		for _, expr := range []string{
+			"",
+			"* * * *",
+			"* * * * * * * *",
+			"60 * * * *",
+			"* 24 * * *",
+			"* * 0 * *",
+			"* * * 13 *",
+			"* * * * 8",
+			"*/0 * * * *",
+			"5-1 * * * *",
+			"* * * JAB *",
+			"* * L * L",
+			"* * 32W * *",
+			"* * * * 5#6",
+			"@sometimes",
+			"TZ=Mars/Olympus * * * * *",
+		} {
+			_, err := Parse(expr, times.MoscowLocation)
+			So(err, ShouldNotBeNil)
+		}
+		_, err := Parse("* * * * *", nil)
+		So(err, ShouldNotBeNil)
+		So(func() { MustParse("* * *", time.UTC) }, ShouldPanic)
+	})
+	Convey("Проверяем String и Location", t, func() {
+		s := MustParse("TZ=UTC 0 9 * * *", times.MoscowLocation)
+		So(s.String(), ShouldEqual, "TZ=UTC 0 9 * * *")
+		So(s.Location(), ShouldEqual, time.UTC)
+	})
+}
+
+func testNext(expr, from string, expected ...string) {
+	Convey(expr, func() {
+		s, err := Parse(expr, times.MoscowLocation)
+		So(err, ShouldBeNil)
+		n := len(expected)
+		if n == 0 {
+			n = 1
+		}
+		So(testStrings(s.NextN(testTime(from), n)), ShouldResemble, append([]string{}, expected...))
+	})
+}
+
+func testTime(s string) times.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	So(err, ShouldBeNil)
+	return times.Time(t)
+}
+
+func testStrings(values []times.Time) []string {
+	result := []string{}
+	for _, t := range values {
+		result = append(result, t.Time().Format(time.RFC3339))
+	}
+	return result
+}
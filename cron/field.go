@@ -0,0 +1,304 @@
+package cron
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bits это множество допустимых значений поля
+type bits uint64
+
+// has возвращает true если значение v допустимо
+func (b bits) has(v int) bool {
+	return v >= 0 && v < 64 && b&(1<<uint(v)) != 0
+}
+
+// bounds это допустимый диапазон значений поля и названия значений
+type bounds struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	secondBounds = bounds{name: "second", min: 0, max: 59}
+	minuteBounds = bounds{name: "minute", min: 0, max: 59}
+	hourBounds   = bounds{name: "hour", min: 0, max: 23}
+	dayBounds    = bounds{name: "day of month", min: 1, max: 31}
+	monthBounds  = bounds{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	weekdayBounds = bounds{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+	yearBounds = bounds{name: "year", min: 1, max: 9999}
+)
+
+// parseRange разбирает элемент поля вида *, a, a-b, */s, a/s или a-b/s
+// и вызывает add для каждого значения
+func parseRange(item string, b bounds, add func(int)) error {
+	expr, step := item, 1
+	if i := strings.IndexByte(item, '/'); i >= 0 {
+		expr = item[:i]
+		var err error
+		step, err = strconv.Atoi(item[i+1:])
+		if err != nil || step < 1 {
+			return fmt.Errorf("cron: invalid step %q in %s field", item, b.name)
+		}
+	}
+	from, to := b.min, b.max
+	switch {
+	case expr == "*" || expr == "?":
+	case strings.IndexByte(expr, '-') > 0:
+		i := strings.IndexByte(expr, '-')
+		var err error
+		from, err = parseValue(expr[:i], b)
+		if err != nil {
+			return err
+		}
+		to, err = parseValue(expr[i+1:], b)
+		if err != nil {
+			return err
+		}
+		if from > to {
+			return fmt.Errorf("cron: invalid range %q in %s field", item, b.name)
+		}
+	default:
+		var err error
+		from, err = parseValue(expr, b)
+		if err != nil {
+			return err
+		}
+		if step == 1 {
+			to = from
+		}
+	}
+	for v := from; v <= to; v += step {
+		add(v)
+	}
+	return nil
+}
+
+// parseValue разбирает число или название значения поля
+func parseValue(s string, b bounds) (int, error) {
+	if v, ok := b.names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("cron: invalid value %q in %s field", s, b.name)
+	}
+	if v < b.min || v > b.max {
+		return 0, fmt.Errorf("cron: value %d out of range [%d, %d] in %s field", v, b.min, b.max, b.name)
+	}
+	return v, nil
+}
+
+// parseBits разбирает поле секунд, минут, часов или месяцев
+func parseBits(field string, b bounds) (bits, error) {
+	var result bits
+	for _, item := range strings.Split(field, ",") {
+		err := parseRange(item, b, func(v int) {
+			result |= 1 << uint(v)
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return result, nil
+}
+
+// isStar возвращает true если поле не ограничивает значения (* или ?)
+// Как в Vixie cron, поле вида */2 тоже считается звёздочкой
+func isStar(field string) bool {
+	return field == "?" || strings.HasPrefix(field, "*")
+}
+
+// dayField это поле дня месяца
+type dayField struct {
+	star bool
+	days bits
+
+	// lastOffsets это смещения от последнего дня месяца: L - 0, L-3 - 3
+	lastOffsets []int
+
+	// lastWeekday это LW - последний рабочий день месяца
+	lastWeekday bool
+
+	// nearestWeekdays это nW - ближайший к дню n рабочий день того же месяца
+	nearestWeekdays []int
+}
+
+// parseDayField разбирает поле дня месяца
+func parseDayField(field string) (dayField, error) {
+	f := dayField{star: isStar(field)}
+	for _, item := range strings.Split(field, ",") {
+		switch {
+		case item == "L":
+			f.lastOffsets = append(f.lastOffsets, 0)
+		case item == "LW":
+			f.lastWeekday = true
+		case strings.HasPrefix(item, "L-"):
+			offset, err := strconv.Atoi(item[2:])
+			if err != nil || offset < 0 || offset > 30 {
+				return dayField{}, fmt.Errorf("cron: invalid value %q in %s field", item, dayBounds.name)
+			}
+			f.lastOffsets = append(f.lastOffsets, offset)
+		case strings.HasSuffix(item, "W"):
+			day, err := parseValue(item[:len(item)-1], dayBounds)
+			if err != nil {
+				return dayField{}, err
+			}
+			f.nearestWeekdays = append(f.nearestWeekdays, day)
+		default:
+			err := parseRange(item, dayBounds, func(v int) {
+				f.days |= 1 << uint(v)
+			})
+			if err != nil {
+				return dayField{}, err
+			}
+		}
+	}
+	return f, nil
+}
+
+// match возвращает true если день d подходит полю
+func (f dayField) match(d time.Time) bool {
+	day := d.Day()
+	if f.days.has(day) {
+		return true
+	}
+	last := daysInMonth(d.Year(), d.Month())
+	for _, offset := range f.lastOffsets {
+		if day == last-offset {
+			return true
+		}
+	}
+	if f.lastWeekday && day == nearestWeekday(d.Year(), d.Month(), last) {
+		return true
+	}
+	for _, n := range f.nearestWeekdays {
+		if n <= last && day == nearestWeekday(d.Year(), d.Month(), n) {
+			return true
+		}
+	}
+	return false
+}
+
+// weekdayField это поле дня недели
+type weekdayField struct {
+	star     bool
+	weekdays bits
+
+	// last это nL - последний такой день недели в месяце
+	last bits
+
+	// nth это n#k - k-й такой день недели в месяце
+	nth []nthWeekday
+}
+
+// nthWeekday это k-й день недели в месяце
+type nthWeekday struct {
+	weekday time.Weekday
+	n       int
+}
+
+// parseWeekdayField разбирает поле дня недели, 7 означает воскресенье
+func parseWeekdayField(field string) (weekdayField, error) {
+	f := weekdayField{star: isStar(field)}
+	for _, item := range strings.Split(field, ",") {
+		switch {
+		case strings.IndexByte(item, '#') > 0:
+			i := strings.IndexByte(item, '#')
+			weekday, err := parseValue(item[:i], weekdayBounds)
+			if err != nil {
+				return weekdayField{}, err
+			}
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n < 1 || n > 5 {
+				return weekdayField{}, fmt.Errorf("cron: invalid value %q in %s field", item, weekdayBounds.name)
+			}
+			f.nth = append(f.nth, nthWeekday{weekday: time.Weekday(weekday % 7), n: n})
+		case len(item) > 1 && strings.HasSuffix(item, "L"):
+			weekday, err := parseValue(item[:len(item)-1], weekdayBounds)
+			if err != nil {
+				return weekdayField{}, err
+			}
+			f.last |= 1 << uint(weekday%7)
+		default:
+			err := parseRange(item, weekdayBounds, func(v int) {
+				f.weekdays |= 1 << uint(v%7)
+			})
+			if err != nil {
+				return weekdayField{}, err
+			}
+		}
+	}
+	return f, nil
+}
+
+// match возвращает true если день d подходит полю
+func (f weekdayField) match(d time.Time) bool {
+	weekday := d.Weekday()
+	if f.weekdays.has(int(weekday)) {
+		return true
+	}
+	if f.last.has(int(weekday)) && d.Day()+7 > daysInMonth(d.Year(), d.Month()) {
+		return true
+	}
+	for _, nth := range f.nth {
+		if nth.weekday == weekday && (d.Day()-1)/7+1 == nth.n {
+			return true
+		}
+	}
+	return false
+}
+
+// parseYears разбирает поле года, * означает любой год
+func parseYears(field string) ([]int, error) {
+	if isStar(field) && !strings.Contains(field, "/") {
+		return nil, nil
+	}
+	seen := map[int]bool{}
+	var result []int
+	for _, item := range strings.Split(field, ",") {
+		err := parseRange(item, yearBounds, func(v int) {
+			if !seen[v] {
+				seen[v] = true
+				result = append(result, v)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Ints(result)
+	return result, nil
+}
+
+// nearestWeekday возвращает ближайший к дню рабочий день того же месяца
+func nearestWeekday(year int, month time.Month, day int) int {
+	switch time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return 3
+		}
+		return day - 1
+	case time.Sunday:
+		if day == daysInMonth(year, month) {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+// daysInMonth возвращает количество дней в месяце
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,277 @@
+package times
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Date это календарная дата без времени и часового пояса
+//
+// Используется стандарт ISO 8601 (YYYY-MM-DD)
+// Пример:
+//   «2005-08-09» - «9 августа 2005 года»
+//
+// Поддерживает следующие форматы при Unmarshalling (XML/JSON/Text/SQL):
+//   2006-01-02
+//   20060102
+// При чтении из базы данных также допускается время после даты:
+//   2006-01-02 15:04:05
+//   2006-01-02T15:04:05Z07:00
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewDate возвращает дату
+// Дата нормализуется, например 2018-02-31 превращается в 2018-03-03
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf возвращает дату метки времени в её часовом поясе
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{
+		Year:  year,
+		Month: month,
+		Day:   day,
+	}
+}
+
+// ParseDate возвращает дату на основе строки
+func ParseDate(s string) (Date, error) {
+	for _, layout := range []string{"2006-01-02", "20060102"} {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return DateOf(t), nil
+		}
+	}
+	return Date{}, fmt.Errorf("times: cannot parse %q as date", s)
+}
+
+// Date возвращает дату метки времени в её часовом поясе
+func (t Time) Date() Date {
+	return DateOf(t.Time())
+}
+
+// time возвращает полночь даты в UTC
+func (d Date) time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// In возвращает начало дня в location
+func (d Date) In(location *time.Location) (*Time, error) {
+	if location == nil {
+		return nil, errors.New("times: empty time location")
+	}
+	t := Time(time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, location))
+	return &t, nil
+}
+
+// IsZero возвращает true для нулевой даты
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// IsValid возвращает true если дата существует в календаре
+func (d Date) IsValid() bool {
+	return DateOf(d.time()) == d
+}
+
+// Weekday возвращает день недели
+func (d Date) Weekday() time.Weekday {
+	return d.time().Weekday()
+}
+
+// YearDay возвращает порядковый номер дня в году
+func (d Date) YearDay() int {
+	return d.time().YearDay()
+}
+
+// AddDays возвращает дату через days дней
+func (d Date) AddDays(days int) Date {
+	return DateOf(d.time().AddDate(0, 0, days))
+}
+
+// AddMonths возвращает дату через months месяцев
+// Если дня нет в полученном месяце - используется последний день месяца,
+// например 2018-01-31 + 1 месяц = 2018-02-28
+func (d Date) AddMonths(months int) Date {
+	first := time.Date(d.Year, d.Month+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	year, month, _ := first.Date()
+	day := d.Day
+	if last := daysIn(year, month); day > last {
+		day = last
+	}
+	return Date{
+		Year:  year,
+		Month: month,
+		Day:   day,
+	}
+}
+
+// AddYears возвращает дату через years лет
+// 29 февраля в невисокосном году превращается в 28 февраля
+func (d Date) AddYears(years int) Date {
+	return d.AddMonths(years * 12)
+}
+
+// DaysSince возвращает количество дней от u до d
+// Считается по номерам дней, поэтому не ограничено диапазоном time.Duration (~292 года)
+func (d Date) DaysSince(u Date) int {
+	return int(d.time().Unix()/secondsPerDay - u.time().Unix()/secondsPerDay)
+}
+
+// secondsPerDay это количество секунд в сутках UTC
+const secondsPerDay = 24 * 60 * 60
+
+// Before возвращает true если d раньше u
+func (d Date) Before(u Date) bool {
+	if d.Year != u.Year {
+		return d.Year < u.Year
+	}
+	if d.Month != u.Month {
+		return d.Month < u.Month
+	}
+	return d.Day < u.Day
+}
+
+// After возвращает true если d позже u
+func (d Date) After(u Date) bool {
+	return u.Before(d)
+}
+
+// Equal возвращает true если даты совпадают
+func (d Date) Equal(u Date) bool {
+	return d == u
+}
+
+// Format возвращает отформатированную дату
+// Функция принимает первый layout
+// В случае если layout не задан - используется формат по умолчанию
+// Формат по умолчанию: "2006-01-02"
+// Для нулевой даты возвращается пустая строка
+func (d Date) Format(layout ...string) string {
+	if d.IsZero() {
+		return ""
+	}
+	if len(layout) == 0 {
+		return d.time().Format("2006-01-02")
+	}
+	return d.time().Format(layout[0])
+}
+
+// String возвращает текстовое представление
+func (d Date) String() string {
+	return d.Format()
+}
+
+// setDateString устанавливает дату из строки
+// Пустая строка соответствует нулевой дате
+func (d *Date) setDateString(data string) error {
+	if data == "" {
+		*d = Date{}
+		return nil
+	}
+	date, err := ParseDate(data)
+	if err != nil {
+		return err
+	}
+	*d = date
+	return nil
+}
+
+// MarshalText это реализация интерфейса encoding.TextMarshaler
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText это реализация интерфейса encoding.TextUnmarshaler
+func (d *Date) UnmarshalText(data []byte) error {
+	return d.setDateString(string(data))
+}
+
+// MarshalJSON необходим для кодирования даты
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON необходим для декодирования даты
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var date string
+	err := json.Unmarshal(data, &date)
+	if err != nil {
+		return err
+	}
+	return d.setDateString(date)
+}
+
+// MarshalXML необходим для кодирования даты
+func (d Date) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(d.String(), start)
+}
+
+// UnmarshalXML необходим для декодирования даты
+func (d *Date) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var data string
+	err := dec.DecodeElement(&data, &start)
+	if err != nil {
+		return err
+	}
+	return d.setDateString(data)
+}
+
+// MarshalXMLAttr необходим для кодирования даты
+func (d Date) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{
+		Name:  name,
+		Value: d.String(),
+	}, nil
+}
+
+// UnmarshalXMLAttr необходим для декодирования даты
+func (d *Date) UnmarshalXMLAttr(attr xml.Attr) error {
+	return d.setDateString(attr.Value)
+}
+
+// Scan это реализация интерфейса database/sql.Scanner
+// Для time.Time используется дата в часовом поясе значения
+func (d *Date) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case time.Time:
+		*d = DateOf(v)
+		return nil
+	case string:
+		return d.setDateString(trimDateTime(v))
+	case []byte:
+		return d.setDateString(trimDateTime(string(v)))
+	}
+	return fmt.Errorf("times: expected value type time.Time, string or []byte but actual %T", src)
+}
+
+// Value это реализация database/sql/driver.Valuer
+// Возвращает полночь даты в UTC, для нулевой даты - time.Time{}
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return time.Time{}, nil
+	}
+	return d.time(), nil
+}
+
+// trimDateTime отбрасывает время после даты в формате YYYY-MM-DD
+func trimDateTime(s string) string {
+	if len(s) > 10 && (s[10] == 'T' || s[10] == ' ') {
+		return s[:10]
+	}
+	return s
+}
+
+// daysIn возвращает количество дней в месяце
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,108 @@
+package times
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDate(t *testing.T) {
+	Convey("Проверяем разбор даты", t, func() {
+		date, err := ParseDate("2018-02-01")
+		So(err, ShouldBeNil)
+		So(date, ShouldResemble, Date{Year: 2018, Month: time.February, Day: 1})
+
+		date, err = ParseDate("20180201")
+		So(err, ShouldBeNil)
+		So(date.String(), ShouldEqual, "2018-02-01")
+
+		_, err = ParseDate("2018-02-31")
+		So(err, ShouldNotBeNil)
+	})
+	Convey("Проверяем арифметику", t, func() {
+		date := NewDate(2018, time.January, 31)
+		So(date.AddDays(1).String(), ShouldEqual, "2018-02-01")
+		So(date.AddDays(-31).String(), ShouldEqual, "2017-12-31")
+		So(date.AddMonths(1).String(), ShouldEqual, "2018-02-28")
+		So(date.AddMonths(-2).String(), ShouldEqual, "2017-11-30")
+		So(date.AddMonths(13).String(), ShouldEqual, "2019-02-28")
+		So(NewDate(2016, time.February, 29).AddYears(1).String(), ShouldEqual, "2017-02-28")
+		So(NewDate(2018, time.March, 1).DaysSince(NewDate(2018, time.February, 1)), ShouldEqual, 28)
+		So(NewDate(2018, time.January, 1).DaysSince(NewDate(1500, time.January, 1)), ShouldEqual, 189196)
+		So(NewDate(1500, time.January, 1).DaysSince(NewDate(2018, time.January, 1)), ShouldEqual, -189196)
+	})
+	Convey("Проверяем сравнение", t, func() {
+		a := NewDate(2018, time.January, 31)
+		b := NewDate(2018, time.February, 1)
+		So(a.Before(b), ShouldBeTrue)
+		So(b.After(a), ShouldBeTrue)
+		So(a.Equal(b), ShouldBeFalse)
+		So(a.Equal(NewDate(2018, time.January, 31)), ShouldBeTrue)
+	})
+	Convey("Проверяем преобразование во время", t, func() {
+		date := NewDate(2018, time.February, 1)
+		result, err := date.In(MoscowLocation)
+		So(err, ShouldBeNil)
+		So(result.String(), ShouldEqual, "2018-02-01T00:00:00+03:00")
+		So(result.Date(), ShouldResemble, date)
+
+		_, err = date.In(nil)
+		So(err, ShouldNotBeNil)
+	})
+	Convey("Проверяем кодирование", t, func() {
+		type Data struct {
+			XMLName  xml.Name `xml:"a" json:"-"`
+			DateAttr Date     `xml:"start,attr" json:"start"`
+			Date     Date     `xml:"date" json:"date"`
+		}
+		data := Data{
+			DateAttr: NewDate(2018, time.February, 1),
+			Date:     NewDate(2018, time.March, 8),
+		}
+		Convey("JSON", func() {
+			result, err := json.Marshal(data)
+			So(err, ShouldBeNil)
+			So(string(result), ShouldEqual, `{"start":"2018-02-01","date":"2018-03-08"}`)
+
+			var decoded Data
+			err = json.Unmarshal(result, &decoded)
+			So(err, ShouldBeNil)
+			So(decoded, ShouldResemble, data)
+		})
+		Convey("XML", func() {
+			result, err := xml.Marshal(data)
+			So(err, ShouldBeNil)
+			So(string(result), ShouldEqual, `<a start="2018-02-01"><date>2018-03-08</date></a>`)
+
+			var decoded Data
+			err = xml.Unmarshal(result, &decoded)
+			So(err, ShouldBeNil)
+			decoded.XMLName = xml.Name{}
+			So(decoded, ShouldResemble, data)
+		})
+		Convey("Пустая дата", func() {
+			var date Date
+			err := json.Unmarshal([]byte(`""`), &date)
+			So(err, ShouldBeNil)
+			So(date.IsZero(), ShouldBeTrue)
+			So(date.String(), ShouldEqual, "")
+		})
+	})
+	Convey("Проверяем database/sql", t, func() {
+		var date Date
+		So(date.Scan(time.Date(2018, time.February, 1, 23, 30, 0, 0, MoscowLocation)), ShouldBeNil)
+		So(date.String(), ShouldEqual, "2018-02-01")
+		So(date.Scan([]byte("2018-03-08")), ShouldBeNil)
+		So(date.String(), ShouldEqual, "2018-03-08")
+		So(date.Scan("2018-03-09 00:00:00"), ShouldBeNil)
+		So(date.String(), ShouldEqual, "2018-03-09")
+		So(date.Scan(1), ShouldNotBeNil)
+
+		value, err := date.Value()
+		So(err, ShouldBeNil)
+		So(value, ShouldResemble, time.Date(2018, time.March, 9, 0, 0, 0, 0, time.UTC))
+	})
+}
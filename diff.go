@@ -0,0 +1,175 @@
+package times
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Difference это календарная разница между двумя метками времени
+//
+// Разложение нормализовано: месяцев меньше 12, дней меньше 7, часов меньше 24 и т.д.
+// Месяцы считаются как в AddMonths с правилом Clamp, поэтому
+// a.AddDate(d.Years, d.Months, d.Weeks*7+d.Days) плюс часы, минуты и секунды даёт b.
+// Например, от 31 января до 28 февраля - 1 месяц, от 29 февраля 2016 до 28 февраля 2017 - 1 год
+//
+// Если b раньше a, Negative равен true, поля разложения неотрицательны,
+// а суммарные значения Total* отрицательны
+type Difference struct {
+	Negative    bool
+	Years       int
+	Months      int
+	Weeks       int
+	Days        int
+	Hours       int
+	Minutes     int
+	Seconds     int
+	Nanoseconds int
+
+	from   time.Time
+	to     time.Time
+	months int
+	days   int
+}
+
+// Diff возвращает календарную разницу b-a, вычисленную по календарю в location
+func Diff(a, b Time, location *time.Location) (*Difference, error) {
+	if location == nil {
+		return nil, errors.New("times: empty time location")
+	}
+	from, to := a.Time().In(location), b.Time().In(location)
+	d := &Difference{}
+	if to.Before(from) {
+		from, to = to, from
+		d.Negative = true
+	}
+	d.from, d.to = from, to
+
+	fromYear, fromMonth, _ := from.Date()
+	toYear, toMonth, _ := to.Date()
+	months := (toYear-fromYear)*12 + int(toMonth-fromMonth)
+	anchor, _ := Time(from).AddMonths(months)
+	if anchor.Time().After(to) {
+		months--
+		anchor, _ = Time(from).AddMonths(months)
+	}
+	d.months = months
+
+	days, anchor := wholeDays(anchor, to)
+	rest := to.Sub(anchor.Time())
+
+	d.Years, d.Months = months/12, months%12
+	d.Weeks, d.Days = days/7, days%7
+	d.Hours = int(rest / time.Hour)
+	d.Minutes = int(rest % time.Hour / time.Minute)
+	d.Seconds = int(rest % time.Minute / time.Second)
+	d.Nanoseconds = int(rest % time.Second)
+
+	d.days, _ = wholeDays(Time(from), to)
+	return d, nil
+}
+
+// wholeDays возвращает количество целых календарных дней от from до to
+// и момент from через это количество дней
+func wholeDays(from Time, to time.Time) (int, Time) {
+	days := DateOf(to).DaysSince(from.Date())
+	result := from.AddDays(days)
+	for days > 0 && result.Time().After(to) {
+		days--
+		result = from.AddDays(days)
+	}
+	return days, result
+}
+
+// sign возвращает -1 для отрицательной разницы и 1 для остальных
+func (d Difference) sign() int {
+	if d.Negative {
+		return -1
+	}
+	return 1
+}
+
+// Period возвращает разницу как продолжительность ISO 8601
+func (d Difference) Period() Period {
+	period := Period{
+		Years:       d.Years,
+		Months:      d.Months,
+		Weeks:       d.Weeks,
+		Days:        d.Days,
+		Hours:       d.Hours,
+		Minutes:     d.Minutes,
+		Seconds:     d.Seconds,
+		Nanoseconds: d.Nanoseconds,
+	}
+	if d.Negative {
+		return period.Negate()
+	}
+	return period
+}
+
+// String возвращает разницу в формате ISO 8601, например P1Y2M3DT4H или -P1M
+func (d Difference) String() string {
+	return d.Period().String()
+}
+
+// Duration возвращает точную разницу
+// Возвращает ошибку если разница не помещается в time.Duration (~292 года)
+func (d Difference) Duration() (time.Duration, error) {
+	seconds, nanoseconds := d.elapsed()
+	if seconds >= math.MaxInt64/int64(time.Second) {
+		return 0, fmt.Errorf("times: difference %s overflows time.Duration", d)
+	}
+	return time.Duration(d.sign()) * (time.Duration(seconds)*time.Second + time.Duration(nanoseconds)), nil
+}
+
+// elapsed возвращает точное время от from до to в секундах и наносекундах
+// Не ограничено диапазоном time.Duration
+func (d Difference) elapsed() (int64, int64) {
+	seconds := d.to.Unix() - d.from.Unix()
+	nanoseconds := int64(d.to.Nanosecond() - d.from.Nanosecond())
+	if nanoseconds < 0 {
+		seconds--
+		nanoseconds += int64(time.Second)
+	}
+	return seconds, nanoseconds
+}
+
+// TotalYears возвращает количество полных лет
+func (d Difference) TotalYears() int {
+	return d.sign() * (d.months / 12)
+}
+
+// TotalMonths возвращает количество полных месяцев
+func (d Difference) TotalMonths() int {
+	return d.sign() * d.months
+}
+
+// TotalWeeks возвращает количество полных недель
+func (d Difference) TotalWeeks() int {
+	return d.sign() * (d.days / 7)
+}
+
+// TotalDays возвращает количество полных календарных дней
+// День перехода на летнее или зимнее время считается одним днём
+func (d Difference) TotalDays() int {
+	return d.sign() * d.days
+}
+
+// TotalHours возвращает количество полных часов точного времени
+func (d Difference) TotalHours() int {
+	seconds, _ := d.elapsed()
+	return d.sign() * int(seconds/(60*60))
+}
+
+// TotalMinutes возвращает количество полных минут точного времени
+func (d Difference) TotalMinutes() int {
+	seconds, _ := d.elapsed()
+	return d.sign() * int(seconds/60)
+}
+
+// TotalSeconds возвращает количество полных секунд точного времени
+func (d Difference) TotalSeconds() int {
+	seconds, _ := d.elapsed()
+	return d.sign() * int(seconds)
+}
@@ -0,0 +1,96 @@
+package times
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDiff(t *testing.T) {
+	Convey("Проверяем разницу", t, func() {
+		testDiff("2018-01-01T00:00:00", "2018-01-01T00:00:00", "P0D")
+		testDiff("2018-01-15T10:00:00", "2019-03-27T12:30:15", "P1Y2M1W5DT2H30M15S")
+		testDiff("2018-01-31T00:00:00", "2018-02-28T00:00:00", "P1M")
+		testDiff("2018-01-31T00:00:00", "2018-03-01T00:00:00", "P1M1D")
+		testDiff("2018-01-31T12:00:00", "2018-02-28T11:00:00", "P3W6DT23H")
+		testDiff("2016-02-29T00:00:00", "2017-02-28T00:00:00", "P1Y")
+		testDiff("2016-02-29T00:00:00", "2020-02-28T00:00:00", "P3Y11M4W2D")
+		testDiff("1990-06-15T00:00:00", "2018-06-14T23:00:00", "P27Y11M4W2DT23H")
+		testDiff("2018-03-27T12:30:15", "2018-01-15T10:00:00", "-P2M1W5DT2H30M15S")
+	})
+	Convey("Проверяем суммарные значения", t, func() {
+		a := Time(time.Date(2018, 1, 15, 10, 0, 0, 0, MoscowLocation))
+		b := Time(time.Date(2019, 3, 27, 12, 30, 15, 0, MoscowLocation))
+		d, err := Diff(a, b, MoscowLocation)
+		So(err, ShouldBeNil)
+		So(d.Negative, ShouldBeFalse)
+		So(d.TotalYears(), ShouldEqual, 1)
+		So(d.TotalMonths(), ShouldEqual, 14)
+		So(d.TotalDays(), ShouldEqual, 436)
+		So(d.TotalWeeks(), ShouldEqual, 62)
+		So(d.TotalHours(), ShouldEqual, 436*24+2)
+		So(d.TotalMinutes(), ShouldEqual, (436*24+2)*60+30)
+		So(d.TotalSeconds(), ShouldEqual, ((436*24+2)*60+30)*60+15)
+
+		d, err = Diff(b, a, MoscowLocation)
+		So(err, ShouldBeNil)
+		So(d.Negative, ShouldBeTrue)
+		So(d.Years, ShouldEqual, 1)
+		So(d.TotalMonths(), ShouldEqual, -14)
+		So(d.TotalDays(), ShouldEqual, -436)
+		duration, err := d.Duration()
+		So(err, ShouldBeNil)
+		So(duration, ShouldEqual, -b.Time().Sub(a.Time()))
+		So(d.Period(), ShouldResemble, Period{Years: -1, Months: -2, Weeks: -1, Days: -5, Hours: -2, Minutes: -30, Seconds: -15})
+
+		_, err = Diff(a, b, nil)
+		So(err, ShouldNotBeNil)
+	})
+	Convey("Проверяем разницу больше 292 лет", t, func() {
+		a := Time(time.Date(1500, 1, 1, 0, 0, 0, 0, time.UTC))
+		b := Time(time.Date(2018, 1, 1, 0, 0, 0, 500, time.UTC))
+		d, err := Diff(a, b, time.UTC)
+		So(err, ShouldBeNil)
+		So(d.String(), ShouldEqual, "P518YT0.0000005S")
+		So(d.TotalDays(), ShouldEqual, 189196)
+		So(d.TotalWeeks(), ShouldEqual, 27028)
+		So(d.TotalHours(), ShouldEqual, 4540704)
+		So(d.TotalMinutes(), ShouldEqual, 4540704*60)
+		So(d.TotalSeconds(), ShouldEqual, 4540704*60*60)
+		_, err = d.Duration()
+		So(err, ShouldNotBeNil)
+
+		d, err = Diff(b, a, time.UTC)
+		So(err, ShouldBeNil)
+		So(d.TotalHours(), ShouldEqual, -4540704)
+		So(d.TotalSeconds(), ShouldEqual, -4540704*60*60)
+	})
+	Convey("Проверяем переход на летнее время", t, func() {
+		location, err := time.LoadLocation("Europe/Berlin")
+		So(err, ShouldBeNil)
+		a := Time(time.Date(2018, 3, 24, 12, 0, 0, 0, location))
+		b := Time(time.Date(2018, 3, 26, 12, 0, 0, 0, location))
+		d, err := Diff(a, b, location)
+		So(err, ShouldBeNil)
+		So(d.String(), ShouldEqual, "P2D")
+		So(d.TotalDays(), ShouldEqual, 2)
+		So(d.TotalHours(), ShouldEqual, 47)
+
+		d, err = Diff(a, b, time.UTC)
+		So(err, ShouldBeNil)
+		So(d.String(), ShouldEqual, "P1DT23H")
+	})
+}
+
+func testDiff(a, b, expected string) {
+	Convey(a+" - "+b, func() {
+		start, err := NewTimeString(a, MoscowLocation)
+		So(err, ShouldBeNil)
+		end, err := NewTimeString(b, MoscowLocation)
+		So(err, ShouldBeNil)
+		d, err := Diff(*start, *end, MoscowLocation)
+		So(err, ShouldBeNil)
+		So(d.String(), ShouldEqual, expected)
+	})
+}
@@ -0,0 +1,235 @@
+package times
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LayoutError это ошибка разбора строки одним форматом
+type LayoutError struct {
+	Layout Layout
+	Err    error
+}
+
+// ParseError это ошибка разбора метки времени
+// Содержит исходную строку, часовой пояс по умолчанию
+// и ошибки всех проверенных форматов
+type ParseError struct {
+	// Field это имя XML элемента или атрибута, если известно
+	Field string
+
+	// Input это исходная строка
+	Input string
+
+	// Location это часовой пояс по умолчанию
+	Location *time.Location
+
+	// Attempts это ошибки всех проверенных форматов в порядке проверки
+	Attempts []LayoutError
+
+	// Offset это смещение в байтах до первого несовпадения
+	// для формата, продвинувшегося дальше остальных
+	// Для значения вне диапазона, например месяца 13, это смещение начала значения
+	Offset int
+}
+
+// Error это реализация интерфейса error
+func (e *ParseError) Error() string {
+	b := &strings.Builder{}
+	b.WriteString("times: ")
+	if e.Field != "" {
+		fmt.Fprintf(b, "%s: ", e.Field)
+	}
+	fmt.Fprintf(b, "cannot parse %q at offset %d", e.Input, e.Offset)
+	if e.Location != nil {
+		fmt.Fprintf(b, " in %s", e.Location)
+	}
+	for i, attempt := range e.Attempts {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString("; ")
+		}
+		fmt.Fprintf(b, "layout %q: %s", attempt.Layout.Layout, layoutErrorMessage(attempt.Err))
+	}
+	return b.String()
+}
+
+// Unwrap возвращает ошибку формата, продвинувшегося дальше остальных
+// При равном смещении лишний текст в конце строки считается менее точной ошибкой
+// Позволяет использовать errors.As с *time.ParseError
+func (e *ParseError) Unwrap() error {
+	best := -1
+	var result error
+	for _, attempt := range e.Attempts {
+		offset := parseErrorOffset(e.Input, attempt.Err)
+		if offset > best || offset == best && isExtraText(result) {
+			best = offset
+			result = attempt.Err
+		}
+	}
+	return result
+}
+
+// newParseError возвращает ошибку разбора с вычисленным смещением
+func newParseError(input string, location *time.Location, attempts []LayoutError) *ParseError {
+	e := &ParseError{
+		Input:    input,
+		Location: location,
+		Attempts: attempts,
+	}
+	for _, attempt := range attempts {
+		offset := parseErrorOffset(input, attempt.Err)
+		if offset > e.Offset {
+			e.Offset = offset
+		}
+	}
+	return e
+}
+
+// withField устанавливает имя поля, если err это *ParseError
+func withField(err error, field string) error {
+	if e, ok := err.(*ParseError); ok && e.Field == "" {
+		e.Field = field
+	}
+	return err
+}
+
+// parseErrorOffset возвращает смещение до первого несовпадения
+// Для значения вне диапазона возвращается смещение начала этого значения
+func parseErrorOffset(input string, err error) int {
+	e, ok := err.(*time.ParseError)
+	if !ok {
+		return 0
+	}
+	if !strings.HasSuffix(input, e.ValueElem) {
+		return 0
+	}
+	offset := len(input) - len(e.ValueElem)
+	if strings.HasSuffix(e.Message, " out of range") {
+		return rangeErrorOffset(input, offset, e)
+	}
+	return offset
+}
+
+// rangeErrorOffset возвращает смещение начала значения вне диапазона,
+// которое заканчивается на смещении end
+func rangeErrorOffset(input string, end int, e *time.ParseError) int {
+	if e.Message == ": day out of range" {
+		// пакет time проверяет день после разбора всей строки и не сообщает, где он
+		return dayOffset(input, e.Layout)
+	}
+	zone := strings.Contains(e.Message, "zone")
+	start := end
+	for start > 0 && (isDigits(input[start-1:start]) || zone && input[start-1] == ':') {
+		start--
+	}
+	if zone && start > 0 && (input[start-1] == '+' || input[start-1] == '-') {
+		start--
+	}
+	return start
+}
+
+// dayOffset возвращает смещение дня вне диапазона:
+// строка разбирается, если заменить день на 28 (или 1 для одной цифры)
+// Если день не найден, возвращается длина строки
+func dayOffset(input, layout string) int {
+	for start := 0; start < len(input); {
+		end := start
+		for end < len(input) && isDigits(input[end:end+1]) {
+			end++
+		}
+		if end == start {
+			start++
+			continue
+		}
+		day := "28"
+		if end-start == 1 {
+			day = "1"
+		}
+		if end-start <= 2 {
+			if _, err := time.Parse(layout, input[:start]+day+input[end:]); err == nil {
+				return start
+			}
+		}
+		start = end
+	}
+	return len(input)
+}
+
+// isExtraText возвращает true если формат совпал, но в строке остался лишний текст
+func isExtraText(err error) bool {
+	e, ok := err.(*time.ParseError)
+	return ok && strings.HasPrefix(e.Message, ": extra text")
+}
+
+// layoutErrorMessage возвращает текст ошибки без повторения формата и строки
+func layoutErrorMessage(err error) string {
+	e, ok := err.(*time.ParseError)
+	if !ok {
+		return err.Error()
+	}
+	if e.Message != "" {
+		return strings.TrimPrefix(e.Message, ": ")
+	}
+	return fmt.Sprintf("cannot parse %q as %q", e.ValueElem, e.LayoutElem)
+}
+
+// DateOverflowError это ошибка прибавления месяцев или лет,
+// если дня исходной даты нет в полученном месяце
+type DateOverflowError struct {
+	// Date это несуществующая дата, например 2018-02-31
+	Date Date
+}
+
+// Error это реализация интерфейса error
+func (e *DateOverflowError) Error() string {
+	return fmt.Sprintf("times: day %d does not exist in %04d-%02d", e.Date.Day, e.Date.Year, int(e.Date.Month))
+}
+
+// LocationError это ошибка загрузки часового пояса
+type LocationError struct {
+	// Name это имя часового пояса
+	Name string
+
+	// Err это исходная ошибка time.LoadLocation
+	Err error
+}
+
+// Error это реализация интерфейса error
+func (e *LocationError) Error() string {
+	return fmt.Sprintf("times: cannot load location %q: %s", e.Name, e.Err)
+}
+
+// Unwrap возвращает исходную ошибку
+func (e *LocationError) Unwrap() error {
+	return e.Err
+}
+
+// AmbiguousZoneError это ошибка поиска часового пояса по сокращению,
+// используемому несколькими поясами, см. AmbiguityPolicy
+type AmbiguousZoneError struct {
+	// Name это сокращение
+	Name string
+
+	// Candidates это подходящие часовые пояса IANA
+	Candidates []string
+}
+
+// Error это реализация интерфейса error
+func (e *AmbiguousZoneError) Error() string {
+	return fmt.Sprintf("times: ambiguous zone %q: %s", e.Name, strings.Join(e.Candidates, ", "))
+}
+
+// CalendarRangeError это ошибка обращения к производственному календарю
+// за год, данных за который в календаре нет, см. BoundedCalendar
+type CalendarRangeError struct {
+	// Year это год без данных
+	Year int
+}
+
+// Error это реализация интерфейса error
+func (e *CalendarRangeError) Error() string {
+	return fmt.Sprintf("times: calendar has no data for year %d", e.Year)
+}
@@ -0,0 +1,76 @@
+package times
+
+import (
+	"encoding/xml"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseError(t *testing.T) {
+	Convey("Проверяем ошибку разбора", t, func() {
+		_, err := NewTimeString("2018-13-25T16:24:28", MoscowLocation)
+		So(err, ShouldNotBeNil)
+
+		var parseErr *ParseError
+		So(errors.As(err, &parseErr), ShouldBeTrue)
+		So(parseErr.Input, ShouldEqual, "2018-13-25T16:24:28")
+		So(parseErr.Location, ShouldEqual, MoscowLocation)
+		So(parseErr.Attempts, ShouldHaveLength, 2)
+		So(parseErr.Attempts[0].Layout.Layout, ShouldEqual, "2006-01-02T15:04:05")
+		So(parseErr.Attempts[1].Layout.Layout, ShouldEqual, "2006-01-02T15:04:05Z07:00")
+		So(parseErr.Offset, ShouldEqual, 5)
+		So(
+			err.Error(),
+			ShouldEqual,
+			`times: cannot parse "2018-13-25T16:24:28" at offset 5 in Europe/Moscow: `+
+				`layout "2006-01-02T15:04:05": month out of range; `+
+				`layout "2006-01-02T15:04:05Z07:00": month out of range`,
+		)
+
+		Convey("Ошибка пакета time доступна через errors.As", func() {
+			var timeErr *time.ParseError
+			So(errors.As(err, &timeErr), ShouldBeTrue)
+			So(timeErr.Layout, ShouldEqual, "2006-01-02T15:04:05")
+		})
+	})
+	Convey("Проверяем смещение по самому длинному совпадению", t, func() {
+		_, err := NewTimeString("2018-01-25T16:24:28+5", MoscowLocation)
+		var parseErr *ParseError
+		So(errors.As(err, &parseErr), ShouldBeTrue)
+		So(parseErr.Offset, ShouldEqual, 19)
+
+		var timeErr *time.ParseError
+		So(errors.As(err, &timeErr), ShouldBeTrue)
+		So(timeErr.Layout, ShouldEqual, "2006-01-02T15:04:05Z07:00")
+	})
+	Convey("Проверяем смещение значения вне диапазона", t, func() {
+		parser := NewParser(Layout{Layout: "2006-01-02T15:04:05Z07:00", Flags: LayoutZone})
+		for input, offset := range map[string]int{
+			"2018-01-25T25:24:28+03:00": 11,
+			"2018-01-25T16:61:28+03:00": 14,
+			"2018-01-25T16:24:61+03:00": 17,
+			"2018-02-30T16:24:28+03:00": 8,
+			"2018-02-29T16:24:28+03:00": 8,
+			"2018-01-25T16:24:28+25:00": 19,
+		} {
+			_, err := parser.Parse(input, MoscowLocation)
+			var parseErr *ParseError
+			So(errors.As(err, &parseErr), ShouldBeTrue)
+			So(parseErr.Offset, ShouldEqual, offset)
+		}
+	})
+	Convey("Проверяем имя XML элемента", t, func() {
+		var data struct {
+			XMLName xml.Name   `xml:"Body"`
+			Date    MoscowTime `xml:"DATE"`
+		}
+		err := xml.Unmarshal([]byte(`<Body><DATE>yesterday</DATE></Body>`), &data)
+		var parseErr *ParseError
+		So(errors.As(err, &parseErr), ShouldBeTrue)
+		So(parseErr.Field, ShouldEqual, "DATE")
+		So(parseErr.Offset, ShouldEqual, 0)
+	})
+}
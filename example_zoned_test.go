@@ -0,0 +1,58 @@
+package times_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mantyr/times"
+)
+
+var YekaterinburgLocation *time.Location
+
+func init() {
+	var err error
+	YekaterinburgLocation, err = time.LoadLocation("Asia/Yekaterinburg")
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Yekb это часовой пояс Asia/Yekaterinburg
+// Особенности:
+//   Принимает любую локаль и преобразует в Asia/Yekaterinburg
+//   В JSON и XML возвращает Asia/Yekaterinburg
+type Yekb struct{}
+
+func (Yekb) Location() *time.Location {
+	return YekaterinburgLocation
+}
+
+func (Yekb) OutputLocation() *time.Location {
+	return YekaterinburgLocation
+}
+
+func (Yekb) Layout() string {
+	return "2006-01-02T15:04:05Z07:00"
+}
+
+// YekaterinburgTime это метка времени в Asia/Yekaterinburg
+type YekaterinburgTime = times.Zoned[Yekb]
+
+func Example_zonedTime() {
+	var data struct {
+		Date YekaterinburgTime `json:"date"`
+	}
+	err := json.Unmarshal([]byte(`{"date":"2018-01-25T16:24:28+03:00"}`), &data)
+	fmt.Println(err)
+	fmt.Println(data.Date)
+
+	result, err := json.Marshal(data)
+	fmt.Println(err)
+	fmt.Println(string(result))
+	// Output:
+	// <nil>
+	// 2018-01-25T18:24:28+05:00
+	// <nil>
+	// {"date":"2018-01-25T18:24:28+05:00"}
+}
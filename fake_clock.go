@@ -0,0 +1,206 @@
+package times
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock это управляемые часы для тестов
+//
+// Время меняется только через Set и Advance,
+// при этом срабатывают все таймеры и тикеры со временем не позже нового.
+// Как и в пакете time, каналы таймеров и тикеров имеют буфер на одно значение,
+// лишние срабатывания тикера отбрасываются
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// NewFakeClock возвращает часы, показывающие now
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// fakeWaiter это таймер или тикер FakeClock
+type fakeWaiter struct {
+	clock  *FakeClock
+	c      chan time.Time
+	when   time.Time
+	period time.Duration
+}
+
+// Now возвращает текущее время часов
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set устанавливает время часов
+// Если время увеличилось, срабатывают таймеры и тикеры по порядку,
+// тикер срабатывает один раз, пропущенные периоды отбрасываются
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for {
+		w := c.next(now)
+		if w == nil {
+			break
+		}
+		if w.when.After(c.now) {
+			c.now = w.when
+		}
+		select {
+		case w.c <- c.now:
+		default:
+		}
+		if w.period > 0 {
+			// В канале уже есть значение, поэтому остальные срабатывания до now были бы отброшены
+			w.when = w.when.Add((now.Sub(w.when)/w.period + 1) * w.period)
+		} else {
+			w.remove()
+		}
+	}
+	c.now = now
+}
+
+// next возвращает ожидание с наименьшим временем не позже now, вызывается под блокировкой
+func (c *FakeClock) next(now time.Time) *fakeWaiter {
+	var result *fakeWaiter
+	for _, w := range c.waiters {
+		if !w.when.After(now) && (result == nil || w.when.Before(result.when)) {
+			result = w
+		}
+	}
+	return result
+}
+
+// Advance сдвигает время часов на d
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Waiters возвращает количество активных таймеров и тикеров
+// Позволяет в тестах дождаться, пока код начнёт ожидание
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// After возвращает канал, в который придёт время через d
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// Sleep ожидает, пока время часов не сдвинется на d
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+// NewTimer возвращает таймер, срабатывающий через d
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	w := &fakeWaiter{
+		clock: c,
+		c:     make(chan time.Time, 1),
+	}
+	w.start(d, 0)
+	return fakeTimer{w}
+}
+
+// NewTicker возвращает тикер с периодом d
+// Паникует если d не положительно, как time.NewTicker
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("times: non-positive interval for NewTicker")
+	}
+	w := &fakeWaiter{
+		clock: c,
+		c:     make(chan time.Time, 1),
+	}
+	w.start(d, d)
+	return fakeTicker{w}
+}
+
+// start запускает ожидание через d, возвращает true если ожидание было активно
+// Ожидание с d <= 0 срабатывает сразу
+func (w *fakeWaiter) start(d, period time.Duration) bool {
+	c := w.clock
+	c.mu.Lock()
+	active := w.remove()
+	w.when = c.now.Add(d)
+	w.period = period
+	if d <= 0 && period == 0 {
+		select {
+		case w.c <- c.now:
+		default:
+		}
+	} else {
+		c.waiters = append(c.waiters, w)
+	}
+	c.mu.Unlock()
+	return active
+}
+
+// stop останавливает ожидание, возвращает true если ожидание было активно
+func (w *fakeWaiter) stop() bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	return w.remove()
+}
+
+// remove удаляет ожидание из часов, вызывается под блокировкой
+func (w *fakeWaiter) remove() bool {
+	for i, waiter := range w.clock.waiters {
+		if waiter == w {
+			w.clock.waiters = append(w.clock.waiters[:i], w.clock.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// fakeTimer это таймер FakeClock
+type fakeTimer struct {
+	*fakeWaiter
+}
+
+// C возвращает канал таймера
+func (t fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+// Stop останавливает таймер, возвращает false если таймер уже сработал или остановлен
+func (t fakeTimer) Stop() bool {
+	return t.stop()
+}
+
+// Reset перезапускает таймер через d, возвращает true если таймер был активен
+func (t fakeTimer) Reset(d time.Duration) bool {
+	return t.start(d, 0)
+}
+
+// fakeTicker это тикер FakeClock
+type fakeTicker struct {
+	*fakeWaiter
+}
+
+// C возвращает канал тикера
+func (t fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+// Stop останавливает тикер
+func (t fakeTicker) Stop() {
+	t.stop()
+}
+
+// Reset перезапускает тикер с периодом d
+// Паникует если d не положительно, как time.Ticker.Reset
+func (t fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("times: non-positive interval for Ticker.Reset")
+	}
+	t.start(d, d)
+}
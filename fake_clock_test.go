@@ -0,0 +1,120 @@
+package times
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	Convey("Проверяем таймеры", t, func() {
+		clock := NewFakeClock(start)
+		timer := clock.NewTimer(time.Hour)
+		after := clock.After(2 * time.Hour)
+		So(clock.Waiters(), ShouldEqual, 2)
+
+		clock.Advance(59 * time.Minute)
+		So(testReceived(timer.C()), ShouldBeFalse)
+
+		clock.Advance(3 * time.Hour)
+		So(clock.Now(), ShouldEqual, start.Add(3*time.Hour+59*time.Minute))
+		fired, ok := testReceive(timer.C())
+		So(ok, ShouldBeTrue)
+		So(fired, ShouldEqual, start.Add(time.Hour))
+		fired, ok = testReceive(after)
+		So(ok, ShouldBeTrue)
+		So(fired, ShouldEqual, start.Add(2*time.Hour))
+		So(clock.Waiters(), ShouldEqual, 0)
+
+		So(timer.Stop(), ShouldBeFalse)
+		So(timer.Reset(time.Minute), ShouldBeFalse)
+		So(timer.Stop(), ShouldBeTrue)
+		clock.Advance(time.Hour)
+		So(testReceived(timer.C()), ShouldBeFalse)
+
+		immediate := clock.NewTimer(0)
+		So(testReceived(immediate.C()), ShouldBeTrue)
+	})
+	Convey("Проверяем тикеры", t, func() {
+		clock := NewFakeClock(start)
+		ticker := clock.NewTicker(time.Minute)
+
+		clock.Advance(time.Minute)
+		fired, ok := testReceive(ticker.C())
+		So(ok, ShouldBeTrue)
+		So(fired, ShouldEqual, start.Add(time.Minute))
+
+		clock.Advance(3 * time.Minute)
+		fired, ok = testReceive(ticker.C())
+		So(ok, ShouldBeTrue)
+		So(fired, ShouldEqual, start.Add(2*time.Minute))
+		So(testReceived(ticker.C()), ShouldBeFalse)
+
+		ticker.Reset(time.Hour)
+		clock.Advance(59 * time.Minute)
+		So(testReceived(ticker.C()), ShouldBeFalse)
+		clock.Advance(time.Minute)
+		So(testReceived(ticker.C()), ShouldBeTrue)
+
+		ticker.Stop()
+		clock.Advance(2 * time.Hour)
+		So(testReceived(ticker.C()), ShouldBeFalse)
+
+		So(func() { clock.NewTicker(0) }, ShouldPanic)
+		So(func() { ticker.Reset(-time.Second) }, ShouldPanic)
+	})
+	Convey("Проверяем частый тикер на большом промежутке", t, func() {
+		clock := NewFakeClock(start)
+		ticker := clock.NewTicker(time.Millisecond)
+		timer := clock.NewTimer(30 * time.Minute)
+
+		began := time.Now()
+		clock.Advance(31 * 24 * time.Hour)
+		So(time.Since(began), ShouldBeLessThan, time.Second)
+
+		fired, ok := testReceive(ticker.C())
+		So(ok, ShouldBeTrue)
+		So(fired, ShouldEqual, start.Add(time.Millisecond))
+		fired, ok = testReceive(timer.C())
+		So(ok, ShouldBeTrue)
+		So(fired, ShouldEqual, start.Add(30*time.Minute))
+
+		clock.Advance(time.Millisecond)
+		fired, ok = testReceive(ticker.C())
+		So(ok, ShouldBeTrue)
+		So(fired, ShouldEqual, start.Add(31*24*time.Hour+time.Millisecond))
+	})
+	Convey("Проверяем Sleep и Set", t, func() {
+		clock := NewFakeClock(start)
+		done := make(chan struct{})
+		go func() {
+			clock.Sleep(time.Hour)
+			close(done)
+		}()
+		for clock.Waiters() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		clock.Set(start.Add(24 * time.Hour))
+		<-done
+		So(clock.Now(), ShouldEqual, start.Add(24*time.Hour))
+
+		clock.Set(start)
+		So(clock.Now(), ShouldEqual, start)
+	})
+}
+
+func testReceive(c <-chan time.Time) (time.Time, bool) {
+	select {
+	case t := <-c:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func testReceived(c <-chan time.Time) bool {
+	_, ok := testReceive(c)
+	return ok
+}
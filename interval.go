@@ -0,0 +1,376 @@
+package times
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// intervalForm это форма записи интервала ISO 8601
+type intervalForm int
+
+const (
+	intervalStartEnd intervalForm = iota
+	intervalStartPeriod
+	intervalPeriodEnd
+	intervalPeriod
+)
+
+// Interval это интервал времени ISO 8601 [Start, End)
+//
+// Поддерживает четыре формы записи:
+//   2018-01-01T00:00:00/2018-02-01T00:00:00 - начало и конец
+//   2018-01-01T00:00:00Z/P1M                - начало и продолжительность
+//   P1M/2018-02-01T00:00:00Z                - продолжительность и конец
+//   P1M                                     - только продолжительность, без привязки ко времени
+// Вместо «/» допускается «--»
+//
+// Время без часового пояса считается временем в location (см. setTimeString),
+// интервал сохраняет исходную форму записи при кодировании
+type Interval struct {
+	Start  Time
+	End    Time
+	Period Period
+	form   intervalForm
+}
+
+// NewInterval возвращает интервал [start, end)
+func NewInterval(start, end Time) (*Interval, error) {
+	if end.Time().Before(start.Time()) {
+		return nil, fmt.Errorf("times: interval end %s is before start %s", end, start)
+	}
+	return &Interval{
+		Start: start,
+		End:   end,
+	}, nil
+}
+
+// NewIntervalStartPeriod возвращает интервал от start продолжительностью period
+func NewIntervalStartPeriod(start Time, period Period) (*Interval, error) {
+	end := start.AddPeriod(period)
+	if end.Time().Before(start.Time()) {
+		return nil, fmt.Errorf("times: negative interval period %s", period)
+	}
+	return &Interval{
+		Start:  start,
+		End:    end,
+		Period: period,
+		form:   intervalStartPeriod,
+	}, nil
+}
+
+// NewIntervalPeriodEnd возвращает интервал продолжительностью period до end
+func NewIntervalPeriodEnd(period Period, end Time) (*Interval, error) {
+	start := end.AddPeriod(period.Negate())
+	if end.Time().Before(start.Time()) {
+		return nil, fmt.Errorf("times: negative interval period %s", period)
+	}
+	return &Interval{
+		Start:  start,
+		End:    end,
+		Period: period,
+		form:   intervalPeriodEnd,
+	}, nil
+}
+
+// ParseInterval возвращает интервал на основе строки ISO 8601
+// Необязательный parser заменяет DefaultParser
+func ParseInterval(s string, location *time.Location, parser ...*Parser) (*Interval, error) {
+	if location == nil {
+		return nil, errors.New("times: empty time location")
+	}
+	p := getParser(parser)
+	left, right, ok := splitInterval(s)
+	if !ok {
+		period, err := ParsePeriod(s)
+		if err != nil {
+			return nil, fmt.Errorf("times: cannot parse %q as interval: %w", s, err)
+		}
+		return &Interval{
+			Period: period,
+			form:   intervalPeriod,
+		}, nil
+	}
+	if strings.HasPrefix(left, "P") {
+		period, err := ParsePeriod(left)
+		if err != nil {
+			return nil, err
+		}
+		end, err := NewTimeString(right, location, p)
+		if err != nil {
+			return nil, err
+		}
+		return NewIntervalPeriodEnd(period, *end)
+	}
+	start, err := NewTimeString(left, location, p)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(right, "P") {
+		period, err := ParsePeriod(right)
+		if err != nil {
+			return nil, err
+		}
+		return NewIntervalStartPeriod(*start, period)
+	}
+	end, err := NewTimeString(right, location, p)
+	if err != nil {
+		return nil, err
+	}
+	return NewInterval(*start, *end)
+}
+
+// splitInterval разделяет интервал на две части по «/» или «--»
+func splitInterval(s string) (string, string, bool) {
+	for _, separator := range []string{"/", "--"} {
+		if i := strings.Index(s, separator); i >= 0 {
+			return s[:i], s[i+len(separator):], true
+		}
+	}
+	return "", "", false
+}
+
+// IsAnchored возвращает false для интервала, заданного только продолжительностью
+func (i Interval) IsAnchored() bool {
+	return i.form != intervalPeriod
+}
+
+// Duration возвращает точную длительность интервала
+func (i Interval) Duration() time.Duration {
+	return i.End.Time().Sub(i.Start.Time())
+}
+
+// IsZero возвращает true для нулевого интервала, который кодируется как пустое значение
+func (i Interval) IsZero() bool {
+	return i.form == intervalStartEnd && i.Start.Time().IsZero() && i.End.Time().IsZero()
+}
+
+// IsEmpty возвращает true если интервал не содержит ни одного момента
+func (i Interval) IsEmpty() bool {
+	return !i.End.Time().After(i.Start.Time())
+}
+
+// Contains возвращает true если t входит в [Start, End)
+func (i Interval) Contains(t Time) bool {
+	value := t.Time()
+	return !value.Before(i.Start.Time()) && value.Before(i.End.Time())
+}
+
+// Overlaps возвращает true если интервалы пересекаются
+func (i Interval) Overlaps(u Interval) bool {
+	return i.Start.Time().Before(u.End.Time()) && u.Start.Time().Before(i.End.Time())
+}
+
+// Intersect возвращает пересечение интервалов в форме начало/конец
+// Второе значение false если интервалы не пересекаются
+func (i Interval) Intersect(u Interval) (Interval, bool) {
+	if !i.Overlaps(u) {
+		return Interval{}, false
+	}
+	start := i.Start
+	if u.Start.Time().After(start.Time()) {
+		start = u.Start
+	}
+	end := i.End
+	if u.End.Time().Before(end.Time()) {
+		end = u.End
+	}
+	return Interval{
+		Start: start,
+		End:   end,
+	}, true
+}
+
+// Format возвращает текстовое представление в исходной форме записи,
+// время приводится к location и форматируется в format
+func (i Interval) Format(location *time.Location, format string) string {
+	switch i.form {
+	case intervalStartPeriod:
+		return i.Start.Time().In(location).Format(format) + "/" + i.Period.String()
+	case intervalPeriodEnd:
+		return i.Period.String() + "/" + i.End.Time().In(location).Format(format)
+	case intervalPeriod:
+		return i.Period.String()
+	}
+	return i.Start.Time().In(location).Format(format) + "/" + i.End.Time().In(location).Format(format)
+}
+
+// String возвращает текстовое представление
+// Время выводится в часовом поясе значений
+func (i Interval) String() string {
+	start := i.Start.String()
+	end := i.End.String()
+	switch i.form {
+	case intervalStartPeriod:
+		return start + "/" + i.Period.String()
+	case intervalPeriodEnd:
+		return i.Period.String() + "/" + end
+	case intervalPeriod:
+		return i.Period.String()
+	}
+	return start + "/" + end
+}
+
+// setIntervalString устанавливает интервал из строки
+// Пустая строка соответствует нулевому интервалу
+func (i *Interval) setIntervalString(data string, location *time.Location, parser *Parser) error {
+	if data == "" {
+		*i = Interval{}
+		return nil
+	}
+	interval, err := ParseInterval(data, location, parser)
+	if err != nil {
+		return err
+	}
+	*i = *interval
+	return nil
+}
+
+// MarshalText это реализация интерфейса encoding.TextMarshaler
+func (i Interval) MarshalText() ([]byte, error) {
+	return i.CustomMarshalText(time.UTC, "2006-01-02T15:04:05Z07:00")
+}
+
+// CustomMarshalText это реализация интерфейса encoding.TextMarshaler
+func (i Interval) CustomMarshalText(location *time.Location, format string) ([]byte, error) {
+	if location == nil {
+		return []byte{}, errors.New("times: empty time location")
+	}
+	if i.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(i.Format(location, format)), nil
+}
+
+// UnmarshalText это реализация интерфейса encoding.TextUnmarshaler
+func (i *Interval) UnmarshalText(data []byte) error {
+	return i.CustomUnmarshalText(data, time.UTC)
+}
+
+// CustomUnmarshalText это реализация интерфейса encoding.TextUnmarshaler
+// Необязательный parser заменяет DefaultParser
+func (i *Interval) CustomUnmarshalText(data []byte, location *time.Location, parser ...*Parser) error {
+	return i.setIntervalString(string(data), location, getParser(parser))
+}
+
+// MarshalJSON необходим для кодирования интервала
+func (i Interval) MarshalJSON() ([]byte, error) {
+	return i.CustomMarshalJSON(time.UTC, "2006-01-02T15:04:05Z07:00")
+}
+
+// CustomMarshalJSON необходим для кодирования интервала
+func (i Interval) CustomMarshalJSON(location *time.Location, format string) ([]byte, error) {
+	if location == nil {
+		return []byte{}, errors.New("times: empty time location")
+	}
+	if i.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(i.Format(location, format))
+}
+
+// UnmarshalJSON необходим для декодирования интервала
+func (i *Interval) UnmarshalJSON(data []byte) error {
+	return i.CustomUnmarshalJSON(data, time.UTC)
+}
+
+// CustomUnmarshalJSON необходим для декодирования интервала
+// JSON null и пустая строка соответствуют нулевому интервалу
+// Необязательный parser заменяет DefaultParser
+func (i *Interval) CustomUnmarshalJSON(data []byte, location *time.Location, parser ...*Parser) error {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	return i.setIntervalString(value, location, getParser(parser))
+}
+
+// MarshalXML необходим для кодирования интервала
+func (i Interval) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return i.CustomMarshalXML(e, start, time.UTC, "2006-01-02T15:04:05Z07:00")
+}
+
+// CustomMarshalXML необходим для кодирования интервала
+func (i Interval) CustomMarshalXML(
+	e *xml.Encoder,
+	start xml.StartElement,
+	location *time.Location,
+	format string,
+) error {
+	if location == nil {
+		return errors.New("times: empty time location")
+	}
+	if i.IsZero() {
+		return e.EncodeElement("", start)
+	}
+	return e.EncodeElement(i.Format(location, format), start)
+}
+
+// UnmarshalXML необходим для декодирования интервала
+func (i *Interval) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return i.CustomUnmarshalXML(d, start, time.UTC)
+}
+
+// CustomUnmarshalXML необходим для декодирования интервала
+// Необязательный parser заменяет DefaultParser
+func (i *Interval) CustomUnmarshalXML(
+	d *xml.Decoder,
+	start xml.StartElement,
+	location *time.Location,
+	parser ...*Parser,
+) error {
+	var data string
+	err := d.DecodeElement(&data, &start)
+	if err != nil {
+		return err
+	}
+	return withField(
+		i.setIntervalString(data, location, getParser(parser)),
+		start.Name.Local,
+	)
+}
+
+// MarshalXMLAttr необходим для кодирования интервала
+func (i Interval) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return i.CustomMarshalXMLAttr(name, time.UTC, "2006-01-02T15:04:05Z07:00")
+}
+
+// CustomMarshalXMLAttr необходим для кодирования интервала
+// Для нулевого интервала атрибут не выводится
+func (i Interval) CustomMarshalXMLAttr(
+	name xml.Name,
+	location *time.Location,
+	format string,
+) (
+	xml.Attr,
+	error,
+) {
+	if location == nil {
+		return xml.Attr{}, errors.New("times: empty time location")
+	}
+	if i.IsZero() {
+		return xml.Attr{}, nil
+	}
+	return xml.Attr{
+		Name:  name,
+		Value: i.Format(location, format),
+	}, nil
+}
+
+// UnmarshalXMLAttr необходим для декодирования интервала
+func (i *Interval) UnmarshalXMLAttr(attr xml.Attr) error {
+	return i.CustomUnmarshalXMLAttr(attr, time.UTC)
+}
+
+// CustomUnmarshalXMLAttr необходим для декодирования интервала
+// Необязательный parser заменяет DefaultParser
+func (i *Interval) CustomUnmarshalXMLAttr(attr xml.Attr, location *time.Location, parser ...*Parser) error {
+	return withField(
+		i.setIntervalString(attr.Value, location, getParser(parser)),
+		attr.Name.Local,
+	)
+}
@@ -0,0 +1,133 @@
+package times
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestInterval(t *testing.T) {
+	Convey("Проверяем разбор интервала", t, func() {
+		testParseInterval(
+			"2018-01-01T00:00:00/2018-02-01T00:00:00",
+			"2018-01-01T00:00:00+03:00",
+			"2018-02-01T00:00:00+03:00",
+		)
+		testParseInterval(
+			"2018-01-01T00:00:00Z/P1M",
+			"2018-01-01T03:00:00+03:00",
+			"2018-02-01T03:00:00+03:00",
+		)
+		testParseInterval(
+			"P1M/2018-02-01T00:00:00Z",
+			"2018-01-01T03:00:00+03:00",
+			"2018-02-01T03:00:00+03:00",
+		)
+		testParseInterval(
+			"2018-01-31T00:00:00--P1M",
+			"2018-01-31T00:00:00+03:00",
+			"2018-02-28T00:00:00+03:00",
+		)
+		Convey("Только продолжительность", func() {
+			interval, err := ParseInterval("P1M", MoscowLocation)
+			So(err, ShouldBeNil)
+			So(interval.IsAnchored(), ShouldBeFalse)
+			So(interval.Period, ShouldResemble, Period{Months: 1})
+			So(interval.String(), ShouldEqual, "P1M")
+		})
+		Convey("Некорректные значения", func() {
+			for _, s := range []string{"2018-02-01T00:00:00/2018-01-01T00:00:00", "2018-01-01T00:00:00/-P1D", "x/P1D", "P1D/x", "x"} {
+				_, err := ParseInterval(s, MoscowLocation)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+	Convey("Проверяем операции", t, func() {
+		january := mustInterval("2018-01-01T00:00:00/2018-02-01T00:00:00")
+		second := mustInterval("2018-01-15T00:00:00/2018-02-15T00:00:00")
+		february := mustInterval("2018-02-01T00:00:00/P1M")
+
+		So(january.Duration(), ShouldEqual, 31*24*time.Hour)
+		So(january.Contains(january.Start), ShouldBeTrue)
+		So(january.Contains(january.End), ShouldBeFalse)
+		So(january.Overlaps(second), ShouldBeTrue)
+		So(january.Overlaps(february), ShouldBeFalse)
+
+		intersection, ok := january.Intersect(second)
+		So(ok, ShouldBeTrue)
+		So(intersection.String(), ShouldEqual, "2018-01-15T00:00:00+03:00/2018-02-01T00:00:00+03:00")
+
+		_, ok = january.Intersect(february)
+		So(ok, ShouldBeFalse)
+	})
+	Convey("Проверяем кодирование", t, func() {
+		type Data struct {
+			XMLName xml.Name `xml:"a" json:"-"`
+			Attr    Interval `xml:"period,attr" json:"attr"`
+			Period  Interval `xml:"period" json:"period"`
+		}
+		source := `{"attr":"2018-01-01T00:00:00Z/P1M","period":"2018-01-01T00:00:00+03:00/2018-01-02T00:00:00+03:00"}`
+		var data Data
+		err := json.Unmarshal([]byte(source), &data)
+		So(err, ShouldBeNil)
+		So(data.Attr.End.String(), ShouldEqual, "2018-02-01T00:00:00Z")
+
+		result, err := json.Marshal(data)
+		So(err, ShouldBeNil)
+		So(string(result), ShouldEqual, `{"attr":"2018-01-01T00:00:00Z/P1M","period":"2017-12-31T21:00:00Z/2018-01-01T21:00:00Z"}`)
+
+		result, err = xml.Marshal(data)
+		So(err, ShouldBeNil)
+		So(string(result), ShouldEqual, `<a period="2018-01-01T00:00:00Z/P1M"><period>2017-12-31T21:00:00Z/2018-01-01T21:00:00Z</period></a>`)
+
+		var interval Interval
+		err = interval.CustomUnmarshalText([]byte("2018-01-01T00:00:00/P1D"), MoscowLocation)
+		So(err, ShouldBeNil)
+		text, err := interval.CustomMarshalText(MoscowLocation, "2006-01-02T15:04:05")
+		So(err, ShouldBeNil)
+		So(string(text), ShouldEqual, "2018-01-01T00:00:00/P1D")
+
+		Convey("Нулевой интервал", func() {
+			var empty Data
+			So(empty.Period.IsZero(), ShouldBeTrue)
+			So(mustInterval("P1M").IsZero(), ShouldBeFalse)
+
+			result, err := json.Marshal(empty)
+			So(err, ShouldBeNil)
+			So(string(result), ShouldEqual, `{"attr":null,"period":null}`)
+			data.Period = mustInterval("P1D")
+			So(json.Unmarshal(result, &data), ShouldBeNil)
+			So(data.Period.IsZero(), ShouldBeTrue)
+			So(data.Attr.IsZero(), ShouldBeTrue)
+
+			result, err = xml.Marshal(empty)
+			So(err, ShouldBeNil)
+			So(string(result), ShouldEqual, `<a><period></period></a>`)
+			So(xml.Unmarshal(result, &data), ShouldBeNil)
+			So(data.Period.IsZero(), ShouldBeTrue)
+
+			text, err := empty.Period.MarshalText()
+			So(err, ShouldBeNil)
+			So(string(text), ShouldEqual, "")
+		})
+	})
+}
+
+func mustInterval(s string) Interval {
+	interval, err := ParseInterval(s, MoscowLocation)
+	So(err, ShouldBeNil)
+	return *interval
+}
+
+func testParseInterval(source, start, end string) {
+	Convey(source, func() {
+		interval, err := ParseInterval(source, MoscowLocation)
+		So(err, ShouldBeNil)
+		So(interval.IsAnchored(), ShouldBeTrue)
+		So(interval.Start.String(), ShouldEqual, start)
+		So(interval.End.String(), ShouldEqual, end)
+	})
+}
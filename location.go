@@ -0,0 +1,57 @@
+package times
+
+import (
+	"sync"
+	"time"
+
+	// Встроенная база часовых поясов используется, если в системе нет zoneinfo
+	// (например в минимальных контейнерах), увеличивает размер программы примерно на 450 КБ
+	_ "time/tzdata"
+)
+
+// locationCache это кэш загруженных часовых поясов по имени
+var locationCache sync.Map
+
+// LoadLocation возвращает часовой пояс по имени IANA, см. time.LoadLocation
+// Часовые пояса кэшируются, при ошибке возвращается *LocationError
+// Благодаря встроенной базе часовых поясов не зависит от наличия zoneinfo в системе
+func LoadLocation(name string) (*time.Location, error) {
+	if location, ok := locationCache.Load(name); ok {
+		return location.(*time.Location), nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, &LocationError{
+			Name: name,
+			Err:  err,
+		}
+	}
+	actual, _ := locationCache.LoadOrStore(name, location)
+	return actual.(*time.Location), nil
+}
+
+// MustLoadLocation возвращает часовой пояс по имени IANA
+// Паникует если часовой пояс не найден
+// Пример:
+//   var Yekaterinburg = times.MustLoadLocation("Asia/Yekaterinburg")
+func MustLoadLocation(name string) *time.Location {
+	location, err := LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
+
+// locationsErr это ошибка загрузки часовых поясов пакета при инициализации
+var locationsErr error
+
+// CheckLocations возвращает ошибку загрузки часовых поясов пакета при инициализации
+// Если ошибки нет, MoscowLocation содержит полную историю Europe/Moscow,
+// иначе MoscowLocation это фиксированное смещение MSK +03:00
+// Пример проверки при запуске сервиса:
+//   if err := times.CheckLocations(); err != nil {
+//       log.Fatal(err)
+//   }
+func CheckLocations() error {
+	return locationsErr
+}
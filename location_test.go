@@ -0,0 +1,43 @@
+package times
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLoadLocation(t *testing.T) {
+	Convey("Проверяем загрузку часовых поясов", t, func() {
+		So(CheckLocations(), ShouldBeNil)
+		So(MoscowLocation, ShouldNotBeNil)
+		So(MoscowLocation.String(), ShouldEqual, "Europe/Moscow")
+
+		location, err := LoadLocation("Asia/Yekaterinburg")
+		So(err, ShouldBeNil)
+		So(location.String(), ShouldEqual, "Asia/Yekaterinburg")
+
+		cached, err := LoadLocation("Asia/Yekaterinburg")
+		So(err, ShouldBeNil)
+		So(cached, ShouldEqual, location)
+		So(MustLoadLocation("Europe/Moscow"), ShouldEqual, MoscowLocation)
+
+		location, err = LoadLocation("")
+		So(err, ShouldBeNil)
+		So(location, ShouldEqual, time.UTC)
+	})
+	Convey("Проверяем ошибку загрузки часового пояса", t, func() {
+		location, err := LoadLocation("Europe/Unknown")
+		So(location, ShouldBeNil)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldStartWith, `times: cannot load location "Europe/Unknown": `)
+
+		var locationErr *LocationError
+		So(errors.As(err, &locationErr), ShouldBeTrue)
+		So(locationErr.Name, ShouldEqual, "Europe/Unknown")
+		So(locationErr.Err, ShouldNotBeNil)
+
+		So(func() { MustLoadLocation("Europe/Unknown") }, ShouldPanic)
+	})
+}
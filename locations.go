@@ -0,0 +1,220 @@
+package times
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AmbiguityPolicy определяет выбор часового пояса
+// для сокращения, используемого несколькими поясами (CST, IST, BST)
+type AmbiguityPolicy int
+
+const (
+	// AmbiguityError возвращает *AmbiguousZoneError
+	AmbiguityError AmbiguityPolicy = iota
+
+	// AmbiguityFirst использует наиболее распространённый часовой пояс
+	AmbiguityFirst
+)
+
+// Locations это реестр часовых поясов
+//
+// Имя часового пояса проверяется по порядку:
+//   пользовательские псевдонимы, см. Alias
+//   имена IANA, включая устаревшие (Europe/Moscow, W-SU)
+//   имена Windows по CLDR (Russian Standard Time)
+//   распространённые сокращения (MSK, PST, CST), см. SetAmbiguity
+//   фиксированные смещения (+03:00, +0300, +03, UTC+3, GMT-05:30)
+// Регистр букв и пробелы по краям не учитываются, кроме регистра имён IANA
+// Найденные часовые пояса кэшируются
+type Locations struct {
+	mu        sync.RWMutex
+	aliases   map[string]string
+	ambiguity AmbiguityPolicy
+
+	// names это кэш имён IANA с учётом регистра
+	names map[string]*time.Location
+
+	// keys это кэш остальных имён в верхнем регистре
+	keys map[string]*time.Location
+
+	// generation увеличивается при изменении псевдонимов и выбора поясов,
+	// чтобы не сохранить в кэш результат, найденный по старым настройкам
+	generation uint64
+}
+
+// NewLocations возвращает реестр часовых поясов
+// По умолчанию неоднозначное сокращение приводит к ошибке
+func NewLocations() *Locations {
+	return &Locations{
+		aliases: map[string]string{},
+		names:   map[string]*time.Location{},
+		keys:    map[string]*time.Location{},
+	}
+}
+
+// DefaultLocations это реестр часовых поясов по умолчанию
+var DefaultLocations = NewLocations()
+
+// Alias добавляет псевдоним часового пояса
+// name это имя IANA, имя Windows, сокращение или смещение
+// Псевдоним проверяется раньше остальных имён, что позволяет
+// разрешить неоднозначное сокращение:
+//   times.DefaultLocations.Alias("CST", "Asia/Shanghai")
+func (l *Locations) Alias(alias, name string) *Locations {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.aliases[strings.ToUpper(strings.TrimSpace(alias))] = name
+	l.reset()
+	return l
+}
+
+// SetAmbiguity устанавливает выбор часового пояса для неоднозначных сокращений
+func (l *Locations) SetAmbiguity(policy AmbiguityPolicy) *Locations {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ambiguity = policy
+	l.reset()
+	return l
+}
+
+// reset очищает кэш, вызывается под блокировкой
+func (l *Locations) reset() {
+	l.names = map[string]*time.Location{}
+	l.keys = map[string]*time.Location{}
+	l.generation++
+}
+
+// Load возвращает часовой пояс по имени
+// Если часовой пояс не найден, возвращает *LocationError,
+// для неоднозначного сокращения при AmbiguityError возвращает *AmbiguousZoneError
+func (l *Locations) Load(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	key := strings.ToUpper(name)
+	if key == "" {
+		return nil, &LocationError{
+			Name: name,
+			Err:  errors.New("empty zone name"),
+		}
+	}
+	l.mu.RLock()
+	alias, isAlias := l.aliases[key]
+	location, ok := l.names[name]
+	if !ok || isAlias {
+		location, ok = l.keys[key]
+	}
+	ambiguity := l.ambiguity
+	generation := l.generation
+	l.mu.RUnlock()
+	if ok {
+		return location, nil
+	}
+
+	exact := false
+	var err error
+	if isAlias {
+		location, _, err = loadZone(alias, ambiguity)
+		if err != nil {
+			return nil, fmt.Errorf("times: alias %q: %w", name, err)
+		}
+	} else {
+		location, exact, err = loadZone(name, ambiguity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.generation == generation {
+		if exact {
+			l.names[name] = location
+		} else {
+			l.keys[key] = location
+		}
+	}
+	return location, nil
+}
+
+// loadZone ищет часовой пояс по имени IANA, имени Windows, сокращению или смещению
+// Второе значение true если найдено имя IANA, которое зависит от регистра
+func loadZone(name string, ambiguity AmbiguityPolicy) (*time.Location, bool, error) {
+	location, err := LoadLocation(name)
+	if err == nil {
+		return location, true, nil
+	}
+	key := strings.ToUpper(name)
+	if windows, ok := windowsZones[key]; ok {
+		location, err := LoadLocation(windows)
+		return location, false, err
+	}
+	if candidates, ok := zoneAbbreviations[key]; ok {
+		if len(candidates) > 1 && ambiguity == AmbiguityError {
+			return nil, false, &AmbiguousZoneError{
+				Name:       name,
+				Candidates: append([]string(nil), candidates...),
+			}
+		}
+		location, err := LoadLocation(candidates[0])
+		return location, false, err
+	}
+	if location, ok := parseZoneOffset(key); ok {
+		return location, false, nil
+	}
+	return nil, false, err
+}
+
+// parseZoneOffset возвращает часовой пояс с фиксированным смещением
+// Поддерживает форматы ±hh, ±h, ±hhmm, ±hh:mm с необязательным префиксом UTC или GMT
+func parseZoneOffset(name string) (*time.Location, bool) {
+	offset := strings.TrimPrefix(strings.TrimPrefix(name, "UTC"), "GMT")
+	if offset == "" || offset[0] != '+' && offset[0] != '-' {
+		return nil, false
+	}
+	sign := 1
+	if offset[0] == '-' {
+		sign = -1
+	}
+	hours, minutes := offset[1:], ""
+	if i := strings.IndexByte(hours, ':'); i >= 0 {
+		hours, minutes = hours[:i], hours[i+1:]
+		if len(minutes) != 2 {
+			return nil, false
+		}
+	} else if len(hours) == 4 {
+		hours, minutes = hours[:2], hours[2:]
+	}
+	if len(hours) == 0 || len(hours) > 2 {
+		return nil, false
+	}
+	h, err := strconv.Atoi(hours)
+	if err != nil || h > 23 {
+		return nil, false
+	}
+	m := 0
+	if minutes != "" {
+		m, err = strconv.Atoi(minutes)
+		if err != nil || m > 59 {
+			return nil, false
+		}
+	}
+	seconds := sign * (h*60*60 + m*60)
+	if seconds == 0 {
+		return time.UTC, true
+	}
+	return time.FixedZone(fixedZoneName(seconds), seconds), true
+}
+
+// fixedZoneName возвращает имя фиксированного смещения в виде ±hh:mm
+func fixedZoneName(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, seconds/(60*60), seconds/60%60)
+}
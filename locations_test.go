@@ -0,0 +1,176 @@
+package times
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLocations(t *testing.T) {
+	Convey("Проверяем реестр часовых поясов", t, func() {
+		locations := NewLocations()
+		testLocation(locations, "Europe/Moscow", "Europe/Moscow")
+		testLocation(locations, "W-SU", "W-SU")
+		testLocation(locations, "Russian Standard Time", "Europe/Moscow")
+		testLocation(locations, "russian standard time", "Europe/Moscow")
+		testLocation(locations, "FLE Standard Time", "Europe/Kiev")
+		testLocation(locations, "MSK", "Europe/Moscow")
+		testLocation(locations, "msk", "Europe/Moscow")
+		testLocation(locations, "PST", "America/Los_Angeles")
+		testLocation(locations, "Z", "UTC")
+		testLocation(locations, "+03:00", "+03:00")
+		testLocation(locations, "+0300", "+03:00")
+		testLocation(locations, "+03", "+03:00")
+		testLocation(locations, "UTC+3", "+03:00")
+		testLocation(locations, "GMT-05:30", "-05:30")
+		testLocation(locations, "+00:00", "UTC")
+
+		location, err := locations.Load("+03:00")
+		So(err, ShouldBeNil)
+		_, offset := time.Date(2018, 1, 1, 0, 0, 0, 0, location).Zone()
+		So(offset, ShouldEqual, 3*60*60)
+
+		cached, err := locations.Load("+03:00")
+		So(err, ShouldBeNil)
+		So(cached, ShouldEqual, location)
+	})
+	Convey("Проверяем кэш", t, func() {
+		locations := NewLocations()
+		for _, name := range []string{"msk", " MSK", "MSK", "Msk "} {
+			testLocation(locations, name, "Europe/Moscow")
+		}
+		So(locations.keys, ShouldHaveLength, 1)
+
+		testLocation(locations, "Europe/Moscow", "Europe/Moscow")
+		So(locations.names, ShouldHaveLength, 1)
+		_, err := locations.Load("europe/moscow")
+		So(err, ShouldNotBeNil)
+
+		locations.SetAmbiguity(AmbiguityFirst)
+		testLocation(locations, "CST", "America/Chicago")
+		locations.SetAmbiguity(AmbiguityError)
+		_, err = locations.Load("cst")
+		So(err, ShouldNotBeNil)
+
+		locations.Alias("Europe/Moscow", "+04:00")
+		testLocation(locations, "Europe/Moscow", "+04:00")
+	})
+	Convey("Проверяем конкурентное изменение настроек", t, func() {
+		locations := NewLocations()
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 100; i++ {
+				locations.SetAmbiguity(AmbiguityFirst)
+				locations.SetAmbiguity(AmbiguityError)
+			}
+		}()
+		for i := 0; i < 100; i++ {
+			locations.Load("CST")
+		}
+		<-done
+		_, err := locations.Load("CST")
+		So(err, ShouldNotBeNil)
+	})
+	Convey("Проверяем имена Windows", t, func() {
+		for name, iana := range windowsZones {
+			_, err := LoadLocation(iana)
+			So(err, ShouldBeNil)
+			_, err = DefaultLocations.Load(name)
+			So(err, ShouldBeNil)
+		}
+		for _, candidates := range zoneAbbreviations {
+			for _, iana := range candidates {
+				_, err := LoadLocation(iana)
+				So(err, ShouldBeNil)
+			}
+		}
+	})
+	Convey("Проверяем неоднозначные сокращения", t, func() {
+		locations := NewLocations()
+		_, err := locations.Load("CST")
+		var ambiguousErr *AmbiguousZoneError
+		So(errors.As(err, &ambiguousErr), ShouldBeTrue)
+		So(ambiguousErr.Name, ShouldEqual, "CST")
+		So(ambiguousErr.Candidates, ShouldResemble, []string{"America/Chicago", "Asia/Shanghai", "America/Havana"})
+		So(err.Error(), ShouldEqual, `times: ambiguous zone "CST": America/Chicago, Asia/Shanghai, America/Havana`)
+
+		locations.SetAmbiguity(AmbiguityFirst)
+		testLocation(locations, "CST", "America/Chicago")
+
+		locations.Alias("cst", "Asia/Shanghai")
+		testLocation(locations, "CST", "Asia/Shanghai")
+
+		locations.Alias("Partner Zone", "Russian Standard Time")
+		testLocation(locations, "partner zone", "Europe/Moscow")
+	})
+	Convey("Проверяем неизвестные часовые пояса", t, func() {
+		locations := NewLocations()
+		for _, name := range []string{"", " ", "Europe/Unknown", "+3:0", "+25:00", "UTC+"} {
+			_, err := locations.Load(name)
+			var locationErr *LocationError
+			So(errors.As(err, &locationErr), ShouldBeTrue)
+		}
+
+		locations.Alias("Partner Zone", "Unknown Zone")
+		_, err := locations.Load("Partner Zone")
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldStartWith, `times: alias "Partner Zone": times: cannot load location "Unknown Zone"`)
+	})
+	Convey("Проверяем разбор имени часового пояса", t, func() {
+		parser := NewParser(
+			Layout{Layout: "2006-01-02T15:04:05Z07:00", Flags: LayoutZone},
+			Layout{Layout: "2006-01-02 15:04:05", Flags: LayoutZoneName},
+		)
+		testParse(parser, "2018-01-25 16:24:28 MSK", "2018-01-25T16:24:28+03:00")
+		testParse(parser, "2018-01-25 16:24:28 Russian Standard Time", "2018-01-25T16:24:28+03:00")
+		testParse(parser, "2018-01-25 16:24:28 [Asia/Yekaterinburg]", "2018-01-25T14:24:28+03:00")
+		testParse(parser, "2018-07-25 16:24:28 Europe/Berlin", "2018-07-25T17:24:28+03:00")
+		testParse(parser, "2018-01-25 16:24:28 +05:00", "2018-01-25T14:24:28+03:00")
+		testParse(parser, "2018-01-25T16:24:28+05:00", "2018-01-25T14:24:28+03:00")
+		Convey("Ошибки", func() {
+			_, err := parser.Parse("2018-01-25 16:24:28", MoscowLocation)
+			So(err, ShouldNotBeNil)
+
+			_, err = parser.Parse("2018-01-25 16:24:28 CST", MoscowLocation)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, `ambiguous zone "CST"`)
+
+			_, err = parser.Parse("2018-01-25 16:24:28 Unknown", MoscowLocation)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("SetLocations", func() {
+			shanghai := parser.SetLocations(NewLocations().Alias("CST", "Asia/Shanghai"))
+			result, err := shanghai.Parse("2018-01-25 16:24:28 CST", time.UTC)
+			So(err, ShouldBeNil)
+			So(result.Format(time.RFC3339), ShouldEqual, "2018-01-25T08:24:28Z")
+		})
+		testParse(parser, "2018-01-25 16:24:28+05:00", "2018-01-25T14:24:28+03:00")
+		Convey("OffsetTime", func() {
+			var date OffsetTime
+			err := date.CustomUnmarshalText([]byte("2018-01-25 16:24:28.5 MSK"), time.UTC, parser)
+			So(err, ShouldBeNil)
+			So(date.String(), ShouldEqual, "2018-01-25 16:24:28.5 +03:00")
+
+			text, err := date.MarshalText()
+			So(err, ShouldBeNil)
+			var decoded OffsetTime
+			err = decoded.CustomUnmarshalText(text, time.UTC, parser)
+			So(err, ShouldBeNil)
+			So(decoded.String(), ShouldEqual, date.String())
+			So(decoded.Time.Time().Equal(date.Time.Time()), ShouldBeTrue)
+
+			err = decoded.CustomUnmarshalText([]byte("2018-01-25 16:24:28 UTC"), time.UTC, parser)
+			So(err, ShouldBeNil)
+			So(decoded.String(), ShouldEqual, "2018-01-25 16:24:28 +00:00")
+		})
+	})
+}
+
+func testLocation(locations *Locations, name, expected string) {
+	location, err := locations.Load(name)
+	So(err, ShouldBeNil)
+	So(location.String(), ShouldEqual, expected)
+}
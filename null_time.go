@@ -0,0 +1,350 @@
+package times
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"time"
+)
+
+// NullTime это метка времени, которая может отсутствовать
+//
+// Отсутствующим (Valid == false) считается значение:
+//   SQL NULL
+//   JSON null или пустая строка
+//   пустой или отсутствующий XML элемент или атрибут
+// При кодировании отсутствующее значение превращается в SQL NULL, JSON null,
+// а XML элемент и атрибут не выводятся
+type NullTime struct {
+	Time  Time
+	Valid bool
+}
+
+// NewNullTime возвращает существующую метку времени в location
+func NewNullTime(t time.Time, location *time.Location) (*NullTime, error) {
+	date, err := NewTime(t, location)
+	if err != nil {
+		return nil, err
+	}
+	return &NullTime{
+		Time:  *date,
+		Valid: true,
+	}, nil
+}
+
+// Ptr возвращает указатель на метку времени или nil если значение отсутствует
+func (n NullTime) Ptr() *Time {
+	if !n.Valid {
+		return nil
+	}
+	t := n.Time
+	return &t
+}
+
+// String возвращает текстовое представление
+// Для отсутствующего значения возвращается пустая строка
+func (n NullTime) String() string {
+	if !n.Valid {
+		return ""
+	}
+	return n.Time.String()
+}
+
+// Scan это реализация интерфейса database/sql.Scanner
+func (n *NullTime) Scan(src interface{}) error {
+	return n.CustomScan(src, time.UTC)
+}
+
+// CustomScan это реализация интерфейса database/sql.Scanner
+// Необязательный parser заменяет SQLParser
+func (n *NullTime) CustomScan(src interface{}, location *time.Location, parser ...*Parser) error {
+	if src == nil {
+		*n = NullTime{}
+		return nil
+	}
+	err := n.Time.CustomScan(src, location, parser...)
+	n.Valid = err == nil
+	return err
+}
+
+// Value это реализация database/sql/driver.Valuer
+func (n NullTime) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Time.Value()
+}
+
+// CustomValue это реализация database/sql/driver.Valuer
+// Для отсутствующего значения возвращает nil
+func (n NullTime) CustomValue(
+	location *time.Location,
+	precision time.Duration,
+	format string,
+) (
+	driver.Value,
+	error,
+) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Time.CustomValue(location, precision, format)
+}
+
+// setNullTimeString устанавливает время из строки
+// Пустая строка означает отсутствующее значение
+func (n *NullTime) setNullTimeString(data string, location *time.Location, parser *Parser) error {
+	if data == "" {
+		*n = NullTime{}
+		return nil
+	}
+	err := n.Time.setTimeString(data, location, parser)
+	n.Valid = err == nil
+	return err
+}
+
+// MarshalJSON необходим для кодирования даты и времени
+func (n NullTime) MarshalJSON() ([]byte, error) {
+	return n.CustomMarshalJSON(time.UTC, "2006-01-02T15:04:05Z07:00")
+}
+
+// CustomMarshalJSON необходим для кодирования даты и времени
+// Необязательный precision заменяет доли секунды в format
+func (n NullTime) CustomMarshalJSON(
+	location *time.Location,
+	format string,
+	precision ...Precision,
+) (
+	[]byte,
+	error,
+) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.Time.CustomMarshalJSON(location, format, precision...)
+}
+
+// UnmarshalJSON необходим для декодирования даты и времени
+func (n *NullTime) UnmarshalJSON(data []byte) error {
+	return n.CustomUnmarshalJSON(data, time.UTC)
+}
+
+// CustomUnmarshalJSON необходим для декодирования даты и времени
+// Необязательный parser заменяет DefaultParser
+func (n *NullTime) CustomUnmarshalJSON(data []byte, location *time.Location, parser ...*Parser) error {
+	if string(data) == "null" {
+		*n = NullTime{}
+		return nil
+	}
+	var date string
+	err := json.Unmarshal(data, &date)
+	if err != nil {
+		return err
+	}
+	return n.setNullTimeString(date, location, getParser(parser))
+}
+
+// MarshalXML необходим для кодирования даты и времени
+func (n NullTime) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return n.CustomMarshalXML(e, start, time.UTC, "2006-01-02T15:04:05Z07:00")
+}
+
+// CustomMarshalXML необходим для кодирования даты и времени
+// Отсутствующее значение не выводится
+// Необязательный precision заменяет доли секунды в format
+func (n NullTime) CustomMarshalXML(
+	e *xml.Encoder,
+	start xml.StartElement,
+	location *time.Location,
+	format string,
+	precision ...Precision,
+) error {
+	if !n.Valid {
+		return nil
+	}
+	return n.Time.CustomMarshalXML(e, start, location, format, precision...)
+}
+
+// UnmarshalXML необходим для декодирования даты и времени
+func (n *NullTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return n.CustomUnmarshalXML(d, start, time.UTC)
+}
+
+// CustomUnmarshalXML необходим для декодирования даты и времени
+// Необязательный parser заменяет DefaultParser
+func (n *NullTime) CustomUnmarshalXML(
+	d *xml.Decoder,
+	start xml.StartElement,
+	location *time.Location,
+	parser ...*Parser,
+) error {
+	var data string
+	err := d.DecodeElement(&data, &start)
+	if err != nil {
+		return err
+	}
+	return withField(
+		n.setNullTimeString(data, location, getParser(parser)),
+		start.Name.Local,
+	)
+}
+
+// MarshalXMLAttr необходим для кодирования даты и времени
+func (n NullTime) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return n.CustomMarshalXMLAttr(name, time.UTC, "2006-01-02T15:04:05Z07:00")
+}
+
+// CustomMarshalXMLAttr необходим для кодирования даты и времени
+// Отсутствующее значение не выводится
+// Необязательный precision заменяет доли секунды в format
+func (n NullTime) CustomMarshalXMLAttr(
+	name xml.Name,
+	location *time.Location,
+	format string,
+	precision ...Precision,
+) (
+	xml.Attr,
+	error,
+) {
+	if !n.Valid {
+		return xml.Attr{}, nil
+	}
+	return n.Time.CustomMarshalXMLAttr(name, location, format, precision...)
+}
+
+// UnmarshalXMLAttr необходим для декодирования даты и времени
+func (n *NullTime) UnmarshalXMLAttr(attr xml.Attr) error {
+	return n.CustomUnmarshalXMLAttr(attr, time.UTC)
+}
+
+// CustomUnmarshalXMLAttr необходим для декодирования даты и времени
+// Необязательный parser заменяет DefaultParser
+func (n *NullTime) CustomUnmarshalXMLAttr(attr xml.Attr, location *time.Location, parser ...*Parser) error {
+	return withField(
+		n.setNullTimeString(attr.Value, location, getParser(parser)),
+		attr.Name.Local,
+	)
+}
+
+// MarshalText это реализация интерфейса encoding.TextMarshaler
+func (n NullTime) MarshalText() ([]byte, error) {
+	return n.CustomMarshalText(time.UTC, "2006-01-02T15:04:05Z07:00")
+}
+
+// CustomMarshalText это реализация интерфейса encoding.TextMarshaler
+// Отсутствующее значение превращается в пустую строку
+// Необязательный precision заменяет доли секунды в format
+func (n NullTime) CustomMarshalText(
+	location *time.Location,
+	format string,
+	precision ...Precision,
+) (
+	[]byte,
+	error,
+) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+	return n.Time.CustomMarshalText(location, format, precision...)
+}
+
+// UnmarshalText это реализация интерфейса encoding.TextUnmarshaler
+func (n *NullTime) UnmarshalText(data []byte) error {
+	return n.CustomUnmarshalText(data, time.UTC)
+}
+
+// CustomUnmarshalText это реализация интерфейса encoding.TextUnmarshaler
+// Необязательный parser заменяет DefaultParser
+func (n *NullTime) CustomUnmarshalText(data []byte, location *time.Location, parser ...*Parser) error {
+	return n.setNullTimeString(string(data), location, getParser(parser))
+}
+
+// NullZoned это метка времени Zoned, которая может отсутствовать
+type NullZoned[Z Zone] struct {
+	NullTime
+}
+
+// String возвращает текстовое представление с учётом PrecisionZone
+func (n NullZoned[Z]) String() string {
+	if !n.Valid {
+		return ""
+	}
+	var zone Z
+	return n.Time.Format(zoneLayout(zone, "2006-01-02T15:04:05Z07:00"))
+}
+
+// NewNullZoned возвращает существующую метку времени в часовом поясе Z.Location()
+func NewNullZoned[Z Zone](t time.Time) (*NullZoned[Z], error) {
+	var zone Z
+	date, err := NewNullTime(t, zone.Location())
+	if err != nil {
+		return nil, err
+	}
+	return &NullZoned[Z]{
+		NullTime: *date,
+	}, nil
+}
+
+// MarshalXML необходим для кодирования даты и времени
+func (n NullZoned[Z]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	var zone Z
+	return n.CustomMarshalXML(e, start, zone.OutputLocation(), zoneLayout(zone, zone.Layout()))
+}
+
+// MarshalXMLAttr необходим для кодирования даты и времени
+func (n NullZoned[Z]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	var zone Z
+	return n.CustomMarshalXMLAttr(name, zone.OutputLocation(), zoneLayout(zone, zone.Layout()))
+}
+
+// UnmarshalXML необходим для декодирования даты и времени
+func (n *NullZoned[Z]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var zone Z
+	return n.CustomUnmarshalXML(d, start, zone.Location())
+}
+
+// UnmarshalXMLAttr необходим для декодирования даты и времени
+func (n *NullZoned[Z]) UnmarshalXMLAttr(attr xml.Attr) error {
+	var zone Z
+	return n.CustomUnmarshalXMLAttr(attr, zone.Location())
+}
+
+// MarshalJSON необходим для кодирования даты и времени
+func (n NullZoned[Z]) MarshalJSON() ([]byte, error) {
+	var zone Z
+	return n.CustomMarshalJSON(zone.OutputLocation(), zoneLayout(zone, zone.Layout()))
+}
+
+// UnmarshalJSON необходим для декодирования даты и времени
+func (n *NullZoned[Z]) UnmarshalJSON(data []byte) error {
+	var zone Z
+	return n.CustomUnmarshalJSON(data, zone.Location())
+}
+
+// MarshalText это реализация интерфейса encoding.TextMarshaler
+func (n NullZoned[Z]) MarshalText() ([]byte, error) {
+	var zone Z
+	return n.CustomMarshalText(zone.OutputLocation(), zoneLayout(zone, zone.Layout()))
+}
+
+// UnmarshalText это реализация интерфейса encoding.TextUnmarshaler
+func (n *NullZoned[Z]) UnmarshalText(data []byte) error {
+	var zone Z
+	return n.CustomUnmarshalText(data, zone.Location())
+}
+
+// Scan это реализация интерфейса database/sql.Scanner
+func (n *NullZoned[Z]) Scan(src interface{}) error {
+	var zone Z
+	return n.CustomScan(src, zone.Location())
+}
+
+// Value это реализация database/sql/driver.Valuer
+// Параметры задаются Z, если Z реализует ValueZone
+func (n NullZoned[Z]) Value() (driver.Value, error) {
+	var zone Z
+	if v, ok := interface{}(zone).(ValueZone); ok {
+		return n.CustomValue(v.ValueLocation(), v.ValuePrecision(), v.ValueLayout())
+	}
+	return n.NullTime.Value()
+}
@@ -0,0 +1,91 @@
+package times
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNullTime(t *testing.T) {
+	Convey("Проверяем database/sql", t, func() {
+		var date NullMoscowTime
+		So(date.Scan(nil), ShouldBeNil)
+		So(date.Valid, ShouldBeFalse)
+
+		value, err := date.Value()
+		So(err, ShouldBeNil)
+		So(value, ShouldBeNil)
+
+		So(date.Scan("2018-01-25T16:24:28"), ShouldBeNil)
+		So(date.Valid, ShouldBeTrue)
+		So(date.String(), ShouldEqual, "2018-01-25T16:24:28+03:00")
+
+		value, err = date.Value()
+		So(err, ShouldBeNil)
+		So(value, ShouldResemble, date.Time.Time())
+	})
+	Convey("Проверяем JSON", t, func() {
+		type Data struct {
+			Date NullMoscowTime `json:"date"`
+		}
+		for _, source := range []string{`{"date":null}`, `{"date":""}`, `{}`} {
+			var data Data
+			err := json.Unmarshal([]byte(source), &data)
+			So(err, ShouldBeNil)
+			So(data.Date.Valid, ShouldBeFalse)
+
+			result, err := json.Marshal(data)
+			So(err, ShouldBeNil)
+			So(string(result), ShouldEqual, `{"date":null}`)
+		}
+
+		var data Data
+		err := json.Unmarshal([]byte(`{"date":"2018-01-25T16:24:28"}`), &data)
+		So(err, ShouldBeNil)
+		So(data.Date.Valid, ShouldBeTrue)
+
+		result, err := json.Marshal(data)
+		So(err, ShouldBeNil)
+		So(string(result), ShouldEqual, `{"date":"2018-01-25T13:24:28Z"}`)
+	})
+	Convey("Проверяем XML", t, func() {
+		type Data struct {
+			XMLName  xml.Name       `xml:"a"`
+			DateAttr NullMoscowTime `xml:"date,attr"`
+			Date     NullMoscowTime `xml:"date"`
+		}
+		for _, source := range []string{`<a date=""><date></date></a>`, `<a><date/></a>`, `<a></a>`} {
+			var data Data
+			err := xml.Unmarshal([]byte(source), &data)
+			So(err, ShouldBeNil)
+			So(data.DateAttr.Valid, ShouldBeFalse)
+			So(data.Date.Valid, ShouldBeFalse)
+
+			result, err := xml.Marshal(data)
+			So(err, ShouldBeNil)
+			So(string(result), ShouldEqual, `<a></a>`)
+		}
+
+		var data Data
+		err := xml.Unmarshal([]byte(`<a date="2018-01-25T16:24:28Z"><date>2018-01-25T16:24:28</date></a>`), &data)
+		So(err, ShouldBeNil)
+		So(data.DateAttr.Valid, ShouldBeTrue)
+		So(data.Date.Valid, ShouldBeTrue)
+
+		result, err := xml.Marshal(data)
+		So(err, ShouldBeNil)
+		So(string(result), ShouldEqual, `<a date="2018-01-25T16:24:28Z"><date>2018-01-25T13:24:28Z</date></a>`)
+	})
+	Convey("Проверяем NullTime", t, func() {
+		date, err := NewNullTime(time.Date(2018, time.January, 25, 16, 24, 28, 0, MoscowLocation), time.UTC)
+		So(err, ShouldBeNil)
+		So(date.Ptr(), ShouldNotBeNil)
+		So(date.String(), ShouldEqual, "2018-01-25T13:24:28Z")
+
+		So(NullTime{}.Ptr(), ShouldBeNil)
+		So(NullTime{}.String(), ShouldEqual, "")
+	})
+}
@@ -0,0 +1,108 @@
+package times
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// NumericUnit описывает интерпретацию числовой метки времени
+type NumericUnit int
+
+const (
+	// UnixSeconds количество секунд с 1970-01-01T00:00:00Z
+	UnixSeconds NumericUnit = iota
+
+	// UnixMillis количество миллисекунд с 1970-01-01T00:00:00Z
+	UnixMillis
+
+	// UnixMicros количество микросекунд с 1970-01-01T00:00:00Z
+	UnixMicros
+
+	// UnixNanos количество наносекунд с 1970-01-01T00:00:00Z
+	UnixNanos
+
+	// JulianDays юлианская дата, количество дней с полудня 24 ноября 4714 года до н.э.
+	// Используется SQLite для значений типа REAL
+	JulianDays
+)
+
+// julianUnixEpoch это юлианская дата 1970-01-01T00:00:00Z
+const julianUnixEpoch = 2440587.5
+
+// String возвращает название единицы
+func (u NumericUnit) String() string {
+	switch u {
+	case UnixSeconds:
+		return "UnixSeconds"
+	case UnixMillis:
+		return "UnixMillis"
+	case UnixMicros:
+		return "UnixMicros"
+	case UnixNanos:
+		return "UnixNanos"
+	case JulianDays:
+		return "JulianDays"
+	}
+	return fmt.Sprintf("NumericUnit(%d)", int(u))
+}
+
+// duration возвращает длительность единицы
+func (u NumericUnit) duration() time.Duration {
+	switch u {
+	case UnixMillis:
+		return time.Millisecond
+	case UnixMicros:
+		return time.Microsecond
+	case UnixNanos:
+		return time.Nanosecond
+	case JulianDays:
+		return 24 * time.Hour
+	}
+	return time.Second
+}
+
+// maxFloatSeconds это наибольшее количество секунд от 1970-01-01T00:00:00Z,
+// которое точно представимо в float64 (около 285 миллионов лет)
+const maxFloatSeconds = 1 << 53
+
+// Int возвращает метку времени на основе целого числа
+// Для JulianDays возвращает ошибку, как и Float
+func (u NumericUnit) Int(v int64) (time.Time, error) {
+	switch u {
+	case UnixMillis:
+		return time.Unix(v/1e3, v%1e3*1e6), nil
+	case UnixMicros:
+		return time.Unix(v/1e6, v%1e6*1e3), nil
+	case UnixNanos:
+		return time.Unix(0, v), nil
+	case JulianDays:
+		return u.Float(float64(v))
+	}
+	return time.Unix(v, 0), nil
+}
+
+// Float возвращает метку времени на основе дробного числа
+// Результат округляется до микросекунды, для JulianDays - до миллисекунды
+// Возвращает ошибку для NaN, бесконечности и значений дальше 2^53 секунд от 1970 года
+func (u NumericUnit) Float(v float64) (time.Time, error) {
+	precision := time.Microsecond
+	if u == JulianDays {
+		v -= julianUnixEpoch
+		precision = time.Millisecond
+	}
+	unit := int64(u.duration())
+	if math.IsNaN(v) || math.Abs(v)*float64(unit)/float64(time.Second) > maxFloatSeconds {
+		return time.Time{}, fmt.Errorf("times: %v %s out of range", v, u)
+	}
+	whole, frac := math.Modf(v)
+	n := int64(whole)
+	var seconds, nanoseconds int64
+	if perSecond := int64(time.Second) / unit; perSecond > 0 {
+		seconds, nanoseconds = n/perSecond, n%perSecond*unit
+	} else {
+		seconds = n * (unit / int64(time.Second))
+	}
+	nanoseconds += int64(frac * float64(unit))
+	return time.Unix(seconds, nanoseconds).Round(precision), nil
+}
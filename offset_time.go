@@ -0,0 +1,236 @@
+package times
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"time"
+)
+
+// OffsetTime это метка времени, сохраняющая исходное смещение UTC
+// и точность долей секунды
+//
+// В отличие от Time не приводит время к часовому поясу по умолчанию:
+//   «2018-01-25T16:24:28.74+05:00» кодируется обратно как «2018-01-25T16:24:28.74+05:00»
+// Время без часового пояса считается временем в location и выводится без часового пояса
+//
+// Приведённое время доступно через In и UTC
+type OffsetTime struct {
+	Time
+	layout string
+}
+
+// NewOffsetTime возвращает метку времени с часовым поясом t
+func NewOffsetTime(t time.Time) *OffsetTime {
+	return &OffsetTime{
+		Time: Time(t),
+	}
+}
+
+// NewOffsetTimeString возвращает метку времени на основе строки
+// Необязательный parser заменяет DefaultParser
+func NewOffsetTimeString(s string, location *time.Location, parser ...*Parser) (*OffsetTime, error) {
+	t := &OffsetTime{}
+	err := t.setOffsetTimeString(s, location, getParser(parser))
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Layout возвращает формат, воспроизводящий исходную строку
+func (t OffsetTime) Layout() string {
+	if t.layout == "" {
+		return "2006-01-02T15:04:05Z07:00"
+	}
+	return t.layout
+}
+
+// Offset возвращает смещение UTC в секундах
+func (t OffsetTime) Offset() int {
+	_, offset := t.Time.Time().Zone()
+	return offset
+}
+
+// In возвращает время, приведённое к location
+func (t OffsetTime) In(location *time.Location) (*Time, error) {
+	return NewTime(t.Time.Time(), location)
+}
+
+// UTC возвращает время, приведённое к UTC
+func (t OffsetTime) UTC() Time {
+	return Time(t.Time.Time().UTC())
+}
+
+// String возвращает текстовое представление в исходном формате
+func (t OffsetTime) String() string {
+	return t.Time.Format(t.Layout())
+}
+
+// setOffsetTimeString устанавливает время из строки с сохранением смещения и точности
+func (t *OffsetTime) setOffsetTimeString(data string, location *time.Location, parser *Parser) error {
+	if data == "" {
+		*t = OffsetTime{}
+		return t.setTimeString(data, location, parser)
+	}
+	result, layout, err := parser.parseLayout(data, location)
+	if err != nil {
+		return err
+	}
+	if layout.Has(LayoutZoneName) {
+		// Имя часового пояса не сохраняется, смещение записывается на его место,
+		// такую строку разбирает тот же формат
+		layout.Layout += " -07:00"
+	}
+	if (layout.Has(LayoutZone) || layout.Has(LayoutZoneName)) && result.Location() != time.UTC {
+		_, offset := result.Zone()
+		result = result.In(time.FixedZone("", offset))
+	}
+	*t = OffsetTime{
+		Time:   Time(result),
+		layout: offsetLayout(layout.Layout, data, result),
+	}
+	return nil
+}
+
+// offsetLayout возвращает формат с исходным количеством знаков долей секунды
+// и исходной записью нулевого смещения (Z или +00:00)
+func offsetLayout(layout string, data string, t time.Time) string {
+	if strings.Contains(layout, "Z07") && !strings.HasSuffix(data, "Z") {
+		layout = strings.Replace(layout, "Z07", "-07", 1)
+	}
+	i := strings.Index(layout, "05")
+	if i < 0 || strings.Contains(layout, "05.") || strings.Contains(layout, "05,") {
+		return layout
+	}
+	prefix := t.Format(layout[:i+2])
+	if !strings.HasPrefix(data, prefix) || len(data) == len(prefix) {
+		return layout
+	}
+	if separator := data[len(prefix)]; separator != '.' && separator != ',' {
+		return layout
+	}
+	digits := 0
+	for _, c := range data[len(prefix)+1:] {
+		if c < '0' || c > '9' {
+			break
+		}
+		digits++
+	}
+	if digits == 0 {
+		return layout
+	}
+	return layout[:i+2] + data[len(prefix):len(prefix)+1] + strings.Repeat("0", digits) + layout[i+2:]
+}
+
+// MarshalJSON необходим для кодирования даты и времени в исходном формате
+func (t OffsetTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON необходим для декодирования даты и времени
+func (t *OffsetTime) UnmarshalJSON(data []byte) error {
+	return t.CustomUnmarshalJSON(data, time.UTC)
+}
+
+// CustomUnmarshalJSON необходим для декодирования даты и времени
+// Необязательный parser заменяет DefaultParser
+func (t *OffsetTime) CustomUnmarshalJSON(data []byte, location *time.Location, parser ...*Parser) error {
+	var date string
+	err := json.Unmarshal(data, &date)
+	if err != nil {
+		return err
+	}
+	return t.setOffsetTimeString(date, location, getParser(parser))
+}
+
+// MarshalXML необходим для кодирования даты и времени в исходном формате
+func (t OffsetTime) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(t.String(), start)
+}
+
+// UnmarshalXML необходим для декодирования даты и времени
+func (t *OffsetTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return t.CustomUnmarshalXML(d, start, time.UTC)
+}
+
+// CustomUnmarshalXML необходим для декодирования даты и времени
+// Необязательный parser заменяет DefaultParser
+func (t *OffsetTime) CustomUnmarshalXML(
+	d *xml.Decoder,
+	start xml.StartElement,
+	location *time.Location,
+	parser ...*Parser,
+) error {
+	var data string
+	err := d.DecodeElement(&data, &start)
+	if err != nil {
+		return err
+	}
+	return withField(
+		t.setOffsetTimeString(data, location, getParser(parser)),
+		start.Name.Local,
+	)
+}
+
+// MarshalXMLAttr необходим для кодирования даты и времени в исходном формате
+func (t OffsetTime) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{
+		Name:  name,
+		Value: t.String(),
+	}, nil
+}
+
+// UnmarshalXMLAttr необходим для декодирования даты и времени
+func (t *OffsetTime) UnmarshalXMLAttr(attr xml.Attr) error {
+	return t.CustomUnmarshalXMLAttr(attr, time.UTC)
+}
+
+// CustomUnmarshalXMLAttr необходим для декодирования даты и времени
+// Необязательный parser заменяет DefaultParser
+func (t *OffsetTime) CustomUnmarshalXMLAttr(attr xml.Attr, location *time.Location, parser ...*Parser) error {
+	return withField(
+		t.setOffsetTimeString(attr.Value, location, getParser(parser)),
+		attr.Name.Local,
+	)
+}
+
+// MarshalText это реализация интерфейса encoding.TextMarshaler
+func (t OffsetTime) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText это реализация интерфейса encoding.TextUnmarshaler
+func (t *OffsetTime) UnmarshalText(data []byte) error {
+	return t.CustomUnmarshalText(data, time.UTC)
+}
+
+// CustomUnmarshalText это реализация интерфейса encoding.TextUnmarshaler
+// Необязательный parser заменяет DefaultParser
+func (t *OffsetTime) CustomUnmarshalText(data []byte, location *time.Location, parser ...*Parser) error {
+	return t.setOffsetTimeString(string(data), location, getParser(parser))
+}
+
+// Scan это реализация интерфейса database/sql.Scanner
+func (t *OffsetTime) Scan(src interface{}) error {
+	return t.CustomScan(src, time.UTC)
+}
+
+// CustomScan это реализация интерфейса database/sql.Scanner
+// time.Time сохраняется с часовым поясом драйвера,
+// строки разбираются с сохранением смещения
+// Необязательный parser заменяет SQLParser
+func (t *OffsetTime) CustomScan(src interface{}, location *time.Location, parser ...*Parser) error {
+	p := getParserOr(parser, SQLParser)
+	switch v := src.(type) {
+	case time.Time:
+		*t = *NewOffsetTime(v)
+		return nil
+	case string:
+		return t.setOffsetTimeString(v, location, p)
+	case []byte:
+		return t.setOffsetTimeString(string(v), location, p)
+	}
+	*t = OffsetTime{}
+	return t.Time.CustomScan(src, location, p)
+}
@@ -0,0 +1,72 @@
+package times
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOffsetTime(t *testing.T) {
+	Convey("Проверяем сохранение исходного формата", t, func() {
+		testOffsetTime("2018-01-25T16:24:28+05:00", "2018-01-25T11:24:28Z")
+		testOffsetTime("2018-01-25T16:24:28.74+05:00", "2018-01-25T11:24:28Z")
+		testOffsetTime("2018-01-25T16:24:28.740-03:30", "2018-01-25T19:54:28Z")
+		testOffsetTime("2018-01-25T16:24:28Z", "2018-01-25T16:24:28Z")
+		testOffsetTime("2018-01-25T16:24:28+00:00", "2018-01-25T16:24:28Z")
+		testOffsetTime("2018-01-25T16:24:28.5", "2018-01-25T13:24:28Z")
+		testOffsetTime("2018-01-25T16:24:28", "2018-01-25T13:24:28Z")
+		Convey("Пользовательский парсер", func() {
+			date, err := NewOffsetTimeString("20180125T162428,5+0500", MoscowLocation, ISO8601Parser)
+			So(err, ShouldBeNil)
+			So(date.String(), ShouldEqual, "20180125T162428,5+0500")
+			So(date.Offset(), ShouldEqual, 5*60*60)
+		})
+	})
+	Convey("Проверяем кодирование", t, func() {
+		type Data struct {
+			XMLName  xml.Name   `xml:"a" json:"-"`
+			DateAttr OffsetTime `xml:"date,attr" json:"start"`
+			Date     OffsetTime `xml:"date" json:"date"`
+		}
+		Convey("JSON", func() {
+			source := `{"start":"2018-01-25T16:24:28.74+05:00","date":"2018-01-25T16:24:28Z"}`
+			var data Data
+			err := json.Unmarshal([]byte(source), &data)
+			So(err, ShouldBeNil)
+			result, err := json.Marshal(data)
+			So(err, ShouldBeNil)
+			So(string(result), ShouldEqual, source)
+		})
+		Convey("XML", func() {
+			source := `<a date="2018-01-25T16:24:28.74+05:00"><date>2018-01-25T16:24:28.100-01:00</date></a>`
+			var data Data
+			err := xml.Unmarshal([]byte(source), &data)
+			So(err, ShouldBeNil)
+			result, err := xml.Marshal(data)
+			So(err, ShouldBeNil)
+			So(string(result), ShouldEqual, source)
+		})
+	})
+	Convey("Проверяем database/sql", t, func() {
+		var date OffsetTime
+		So(date.CustomScan("2018-01-25 16:24:28.74+05", MoscowLocation), ShouldBeNil)
+		So(date.String(), ShouldEqual, "2018-01-25 16:24:28.74+05")
+		So(date.Scan(int64(1516886668)), ShouldBeNil)
+		So(date.String(), ShouldEqual, "2018-01-25T13:24:28Z")
+	})
+}
+
+func testOffsetTime(source, utc string) {
+	Convey(source, func() {
+		date, err := NewOffsetTimeString(source, MoscowLocation)
+		So(err, ShouldBeNil)
+		So(date.String(), ShouldEqual, source)
+		So(date.UTC().String(), ShouldEqual, utc)
+
+		normalized, err := date.In(MoscowLocation)
+		So(err, ShouldBeNil)
+		So(normalized.DeepEqual(date.UTC()), ShouldBeTrue)
+	})
+}
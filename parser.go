@@ -0,0 +1,290 @@
+package times
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// LayoutFlag описывает особенности формата
+type LayoutFlag uint
+
+const (
+	// LayoutZone формат содержит часовой пояс,
+	// location используется только для приведения результата
+	LayoutZone LayoutFlag = 1 << iota
+
+	// LayoutDateOnly формат содержит только дату,
+	// результат соответствует началу дня в location
+	LayoutDateOnly
+
+	// LayoutBasic базовый формат ISO 8601 без разделителей,
+	// например 20060102T150405
+	LayoutBasic
+
+	// LayoutZoneName после формата через пробел следует имя часового пояса,
+	// которое ищется в Locations парсера, например
+	// «2018-01-25 16:24:28 MSK», «2018-01-25 16:24:28 [Europe/Moscow]»
+	// или «2018-01-25 16:24:28+03:00»
+	LayoutZoneName
+)
+
+// Layout это формат метки времени в терминах пакета time
+type Layout struct {
+	Layout string
+	Flags  LayoutFlag
+}
+
+// Has возвращает true если у формата установлены все флаги
+func (l Layout) Has(flags LayoutFlag) bool {
+	return l.Flags&flags == flags
+}
+
+// parse разбирает строку по формату
+func (l Layout) parse(data string, location *time.Location, locations *Locations) (time.Time, error) {
+	if l.Has(LayoutZoneName) {
+		return l.parseZoneName(data, locations)
+	}
+	if l.Has(LayoutZone) {
+		return time.Parse(l.Layout, data)
+	}
+	return time.ParseInLocation(l.Layout, data, location)
+}
+
+// parseZoneName разбирает строку с именем часового пояса после пробела
+// Имя может содержать пробелы (Russian Standard Time) и быть в квадратных скобках,
+// числовое смещение допускается и без пробела
+func (l Layout) parseZoneName(data string, locations *Locations) (time.Time, error) {
+	var zoneErr error
+	for i := 0; i < len(data); i++ {
+		if data[i] != ' ' {
+			continue
+		}
+		value := data[:i]
+		if _, err := time.Parse(l.Layout, value); err != nil {
+			continue
+		}
+		name := strings.TrimSpace(data[i+1:])
+		if strings.HasPrefix(name, "[") && strings.HasSuffix(name, "]") {
+			name = name[1 : len(name)-1]
+		}
+		if name == "" {
+			continue
+		}
+		location, err := locations.Load(name)
+		if err != nil {
+			zoneErr = err
+			continue
+		}
+		return time.ParseInLocation(l.Layout, value, location)
+	}
+	if zoneErr != nil {
+		return time.Time{}, zoneErr
+	}
+	if result, err := time.Parse(l.Layout+"Z07:00", data); err == nil {
+		return result, nil
+	}
+	_, err := time.Parse(l.Layout, data)
+	if err == nil {
+		err = fmt.Errorf("times: missing zone name in %q", data)
+	}
+	return time.Time{}, err
+}
+
+// Parser это упорядоченный список форматов
+// Форматы проверяются по порядку, используется первый подходящий
+//
+// Числовые значения из базы данных интерпретируются по умолчанию так:
+//   int64   - UnixSeconds
+//   float64 - JulianDays
+// Нулевое значение Parser использует те же значения по умолчанию, что и NewParser
+type Parser struct {
+	layouts   []Layout
+	intUnit   NumericUnit
+	floatUnit NumericUnit
+	numeric   bool
+	locations *Locations
+}
+
+// NewParser возвращает парсер со списком форматов
+func NewParser(layouts ...Layout) *Parser {
+	p := &Parser{}
+	p.layouts = append(p.layouts, layouts...)
+	return p
+}
+
+// DefaultParser это парсер по умолчанию
+// Парсеры по умолчанию не изменяются и безопасны для использования из нескольких горутин,
+// для своего списка форматов используйте копию, например DefaultParser.Add(...)
+// Поддерживает форматы:
+//   2006-01-02T15:04:05                 - локальное время в location
+//   2006-01-02T15:04:05.999999999       - доли секунды
+//   2006-01-02T15:04:05Z07:00           - время с часовым поясом
+//   2006-01-02T15:04:05.999999999Z07:00 - время с часовым поясом и долями секунды
+var DefaultParser = NewParser(
+	Layout{Layout: "2006-01-02T15:04:05"},
+	Layout{Layout: "2006-01-02T15:04:05Z07:00", Flags: LayoutZone},
+)
+
+// ISO8601Parser это парсер распространённых вариантов ISO 8601
+// Помимо форматов DefaultParser поддерживает:
+//   2006-01-02 15:04:05[Z07:00] - пробел вместо T
+//   2006-01-02T15:04[Z07:00]    - без секунд
+//   2006-01-02                  - только дата
+//   20060102T150405[Z0700]      - базовый формат
+//   20060102                    - базовый формат, только дата
+var ISO8601Parser = NewParser(
+	Layout{Layout: "2006-01-02T15:04:05"},
+	Layout{Layout: "2006-01-02T15:04:05Z07:00", Flags: LayoutZone},
+	Layout{Layout: "2006-01-02 15:04:05"},
+	Layout{Layout: "2006-01-02 15:04:05Z07:00", Flags: LayoutZone},
+	Layout{Layout: "2006-01-02T15:04"},
+	Layout{Layout: "2006-01-02T15:04Z07:00", Flags: LayoutZone},
+	Layout{Layout: "2006-01-02", Flags: LayoutDateOnly},
+	Layout{Layout: "20060102T150405", Flags: LayoutBasic},
+	Layout{Layout: "20060102T150405Z0700", Flags: LayoutBasic | LayoutZone},
+	Layout{Layout: "20060102", Flags: LayoutBasic | LayoutDateOnly},
+)
+
+// SQLParser это парсер по умолчанию для database/sql.Scanner
+// Помимо форматов DefaultParser поддерживает пробел вместо T:
+//   2006-01-02 15:04:05[.999999999]        - MySQL, ClickHouse, SQLite
+//   2006-01-02 15:04:05[.999999999]Z07:00  - время с часовым поясом
+//   2006-01-02 15:04:05[.999999999]Z07     - PostgreSQL timestamptz
+var SQLParser = NewParser(
+	Layout{Layout: "2006-01-02T15:04:05"},
+	Layout{Layout: "2006-01-02T15:04:05Z07:00", Flags: LayoutZone},
+	Layout{Layout: "2006-01-02 15:04:05"},
+	Layout{Layout: "2006-01-02 15:04:05Z07:00", Flags: LayoutZone},
+	Layout{Layout: "2006-01-02 15:04:05Z07", Flags: LayoutZone},
+)
+
+// clone возвращает копию парсера со своим списком форматов
+func (p *Parser) clone() *Parser {
+	c := *p
+	c.layouts = p.Layouts()
+	return &c
+}
+
+// Add возвращает копию парсера с форматом в конце списка
+// Исходный парсер не изменяется
+func (p *Parser) Add(layout string, flags LayoutFlag) *Parser {
+	c := p.clone()
+	c.layouts = append(c.layouts, Layout{Layout: layout, Flags: flags})
+	return c
+}
+
+// SetNumeric возвращает копию парсера с интерпретацией целых и дробных числовых значений
+// Исходный парсер не изменяется
+func (p *Parser) SetNumeric(intUnit, floatUnit NumericUnit) *Parser {
+	c := p.clone()
+	c.intUnit = intUnit
+	c.floatUnit = floatUnit
+	c.numeric = true
+	return c
+}
+
+// SetLocations возвращает копию парсера с реестром часовых поясов для форматов с LayoutZoneName
+// nil означает DefaultLocations. Исходный парсер не изменяется
+func (p *Parser) SetLocations(locations *Locations) *Parser {
+	c := p.clone()
+	c.locations = locations
+	return c
+}
+
+// Layouts возвращает копию списка форматов
+func (p *Parser) Layouts() []Layout {
+	return append([]Layout(nil), p.layouts...)
+}
+
+// Parse разбирает строку первым подходящим форматом
+// Время без часового пояса считается временем в location,
+// результат приводится к location
+// В случае ошибки возвращает *ParseError со всеми проверенными форматами
+func (p *Parser) Parse(data string, location *time.Location) (time.Time, error) {
+	result, _, err := p.parseLayout(data, location)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return result.In(location), nil
+}
+
+// parseLayout разбирает строку первым подходящим форматом без приведения к location
+// и возвращает использованный формат
+func (p *Parser) parseLayout(data string, location *time.Location) (time.Time, Layout, error) {
+	if location == nil {
+		return time.Time{}, Layout{}, errors.New("times: empty time location")
+	}
+	if len(p.layouts) == 0 {
+		return time.Time{}, Layout{}, errors.New("times: empty parser layouts")
+	}
+	locations := p.locations
+	if locations == nil {
+		locations = DefaultLocations
+	}
+	attempts := make([]LayoutError, 0, len(p.layouts))
+	for _, layout := range p.layouts {
+		result, err := layout.parse(data, location, locations)
+		if err == nil {
+			return result, layout, nil
+		}
+		attempts = append(attempts, LayoutError{
+			Layout: layout,
+			Err:    err,
+		})
+	}
+	return time.Time{}, Layout{}, newParseError(data, location, attempts)
+}
+
+// ParseInt возвращает метку времени в location на основе целого числа
+func (p *Parser) ParseInt(v int64, location *time.Location) (time.Time, error) {
+	if location == nil {
+		return time.Time{}, errors.New("times: empty time location")
+	}
+	intUnit, _ := p.numericUnits()
+	result, err := intUnit.Int(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return result.In(location), nil
+}
+
+// ParseFloat возвращает метку времени в location на основе дробного числа
+func (p *Parser) ParseFloat(v float64, location *time.Location) (time.Time, error) {
+	if location == nil {
+		return time.Time{}, errors.New("times: empty time location")
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return time.Time{}, fmt.Errorf("times: cannot use %v as time", v)
+	}
+	_, floatUnit := p.numericUnits()
+	result, err := floatUnit.Float(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return result.In(location), nil
+}
+
+// numericUnits возвращает интерпретацию целых и дробных числовых значений
+// с учётом значений по умолчанию, если SetNumeric не вызывался
+func (p *Parser) numericUnits() (NumericUnit, NumericUnit) {
+	if !p.numeric {
+		return UnixSeconds, JulianDays
+	}
+	return p.intUnit, p.floatUnit
+}
+
+// getParser возвращает первый заданный парсер или DefaultParser
+func getParser(parser []*Parser) *Parser {
+	return getParserOr(parser, DefaultParser)
+}
+
+// getParserOr возвращает первый заданный парсер или defaultParser
+func getParserOr(parser []*Parser, defaultParser *Parser) *Parser {
+	if len(parser) == 0 || parser[0] == nil {
+		return defaultParser
+	}
+	return parser[0]
+}
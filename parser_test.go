@@ -0,0 +1,75 @@
+package times
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParser(t *testing.T) {
+	Convey("Проверяем DefaultParser", t, func() {
+		testParse(DefaultParser, "2018-01-25T16:24:28", "2018-01-25T16:24:28+03:00")
+		testParse(DefaultParser, "2018-01-25T16:24:28.74", "2018-01-25T16:24:28.74+03:00")
+		testParse(DefaultParser, "2018-01-25T16:24:28Z", "2018-01-25T19:24:28+03:00")
+		testParse(DefaultParser, "2018-01-25T16:24:28.74+05:00", "2018-01-25T14:24:28.74+03:00")
+		Convey("Неподдерживаемый формат", func() {
+			_, err := DefaultParser.Parse("2018-01-25", MoscowLocation)
+			So(err, ShouldNotBeNil)
+		})
+	})
+	Convey("Проверяем ISO8601Parser", t, func() {
+		testParse(ISO8601Parser, "2018-01-25 16:24:28", "2018-01-25T16:24:28+03:00")
+		testParse(ISO8601Parser, "2018-01-25 16:24:28+05:00", "2018-01-25T14:24:28+03:00")
+		testParse(ISO8601Parser, "2018-01-25T16:24", "2018-01-25T16:24:00+03:00")
+		testParse(ISO8601Parser, "2018-01-25", "2018-01-25T00:00:00+03:00")
+		testParse(ISO8601Parser, "20180125T162428", "2018-01-25T16:24:28+03:00")
+		testParse(ISO8601Parser, "20180125T162428+0500", "2018-01-25T14:24:28+03:00")
+		testParse(ISO8601Parser, "20180125", "2018-01-25T00:00:00+03:00")
+	})
+	Convey("Проверяем пользовательский парсер", t, func() {
+		parser := NewParser().Add("02.01.2006 15:04", 0)
+		So(parser.Layouts(), ShouldResemble, []Layout{{Layout: "02.01.2006 15:04"}})
+		Convey("Add и SetNumeric не изменяют исходный парсер", func() {
+			layouts := DefaultParser.Layouts()
+			custom := DefaultParser.Add("02.01.2006 15:04", 0).SetNumeric(UnixMillis, UnixMillis)
+			So(DefaultParser.Layouts(), ShouldResemble, layouts)
+			So(custom.Layouts(), ShouldHaveLength, len(layouts)+1)
+			So(parser.Add("2006-01-02", 0).Layouts(), ShouldHaveLength, 2)
+			So(parser.Layouts(), ShouldHaveLength, 1)
+		})
+		Convey("NewTimeString", func() {
+			date, err := NewTimeString("25.01.2018 16:24", MoscowLocation, parser)
+			So(err, ShouldBeNil)
+			So(date.String(), ShouldEqual, "2018-01-25T16:24:00+03:00")
+		})
+		Convey("CustomUnmarshalJSON", func() {
+			var date Time
+			err := date.CustomUnmarshalJSON([]byte(`"25.01.2018 16:24"`), MoscowLocation, parser)
+			So(err, ShouldBeNil)
+			So(date.String(), ShouldEqual, "2018-01-25T16:24:00+03:00")
+		})
+		Convey("CustomScan", func() {
+			var date Time
+			err := date.CustomScan("25.01.2018 16:24", MoscowLocation, parser)
+			So(err, ShouldBeNil)
+			So(date.String(), ShouldEqual, "2018-01-25T16:24:00+03:00")
+		})
+		Convey("Пустой парсер", func() {
+			_, err := NewParser().Parse("2018-01-25T16:24:28", MoscowLocation)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func testParse(parser *Parser, source, expected string) {
+	Convey(source, func() {
+		result, err := parser.Parse(source, MoscowLocation)
+		So(err, ShouldBeNil)
+		So(
+			result.Format(time.RFC3339Nano),
+			ShouldEqual,
+			expected,
+		)
+	})
+}
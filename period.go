@@ -0,0 +1,408 @@
+package times
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period это продолжительность ISO 8601 (xs:duration)
+//
+// Формат: [-]PnYnMnWnDTnHnMn.nS
+// Пример:
+//   «P1Y2M10DT2H30M» - «1 год 2 месяца 10 дней 2 часа 30 минут»
+//   «-P1D»           - «минус 1 день»
+//   «PT0.5S»         - «полсекунды»
+//
+// Годы, месяцы, недели и дни это календарная часть, зависящая от даты и часового пояса,
+// часы, минуты и секунды это точная часть (см. Exact)
+// Доли допускаются только у секунд
+type Period struct {
+	Years       int
+	Months      int
+	Weeks       int
+	Days        int
+	Hours       int
+	Minutes     int
+	Seconds     int
+	Nanoseconds int
+}
+
+// ParsePeriod возвращает продолжительность на основе строки ISO 8601
+// Помимо знака перед P допускается знак у каждой части,
+// как в String и в PostgreSQL interval в стиле iso_8601, например P1M-1D или P-1Y-2M3DT-4H
+func ParsePeriod(s string) (Period, error) {
+	invalid := fmt.Errorf("times: cannot parse %q as ISO 8601 duration", s)
+	data := s
+	negative := strings.HasPrefix(data, "-")
+	if negative {
+		data = data[1:]
+	}
+	if !strings.HasPrefix(data, "P") || len(data) == 1 {
+		return Period{}, invalid
+	}
+	data = data[1:]
+
+	p := Period{}
+	designators := "YMWD"
+	clock := false
+	for data != "" {
+		if data[0] == 'T' {
+			if clock || len(data) == 1 {
+				return Period{}, invalid
+			}
+			clock = true
+			designators = "HMS"
+			data = data[1:]
+			continue
+		}
+		sign := 1
+		switch data[0] {
+		case '-':
+			sign = -1
+			data = data[1:]
+		case '+':
+			data = data[1:]
+		}
+		n := strings.IndexFunc(data, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.' && r != ','
+		})
+		if n <= 0 {
+			return Period{}, invalid
+		}
+		number, designator := data[:n], data[n]
+		data = data[n+1:]
+
+		i := strings.IndexByte(designators, designator)
+		if i < 0 {
+			return Period{}, invalid
+		}
+		designators = designators[i+1:]
+
+		fraction := ""
+		if j := strings.IndexAny(number, ".,"); j >= 0 {
+			if designator != 'S' || !clock {
+				return Period{}, invalid
+			}
+			number, fraction = number[:j], number[j+1:]
+			if number == "" || fraction == "" || len(fraction) > 9 || !isDigits(fraction) {
+				return Period{}, invalid
+			}
+		}
+		value, err := strconv.Atoi(number)
+		if err != nil {
+			return Period{}, invalid
+		}
+		value *= sign
+		switch {
+		case !clock && designator == 'Y':
+			p.Years = value
+		case !clock && designator == 'M':
+			p.Months = value
+		case !clock && designator == 'W':
+			p.Weeks = value
+		case !clock && designator == 'D':
+			p.Days = value
+		case designator == 'H':
+			p.Hours = value
+		case designator == 'M':
+			p.Minutes = value
+		case designator == 'S':
+			p.Seconds = value
+			if fraction != "" {
+				p.Nanoseconds, _ = strconv.Atoi(fraction + strings.Repeat("0", 9-len(fraction)))
+				p.Nanoseconds *= sign
+			}
+		}
+	}
+	if clock && designators == "HMS" {
+		return Period{}, invalid
+	}
+	if negative {
+		p = p.Negate()
+	}
+	return p, nil
+}
+
+// IsZero возвращает true для нулевой продолжительности
+func (p Period) IsZero() bool {
+	return p == Period{}
+}
+
+// Negate возвращает продолжительность с противоположным знаком
+func (p Period) Negate() Period {
+	return Period{
+		Years:       -p.Years,
+		Months:      -p.Months,
+		Weeks:       -p.Weeks,
+		Days:        -p.Days,
+		Hours:       -p.Hours,
+		Minutes:     -p.Minutes,
+		Seconds:     -p.Seconds,
+		Nanoseconds: -p.Nanoseconds,
+	}
+}
+
+// Exact возвращает точную часть продолжительности (часы, минуты, секунды)
+func (p Period) Exact() time.Duration {
+	return time.Duration(p.Hours)*time.Hour +
+		time.Duration(p.Minutes)*time.Minute +
+		time.Duration(p.Seconds)*time.Second +
+		time.Duration(p.Nanoseconds)
+}
+
+// isNegative возвращает true если все части продолжительности не положительные
+func (p Period) isNegative() bool {
+	values := []int{p.Years, p.Months, p.Weeks, p.Days, p.Hours, p.Minutes, p.Seconds, p.Nanoseconds}
+	negative := false
+	for _, value := range values {
+		if value > 0 {
+			return false
+		}
+		if value < 0 {
+			negative = true
+		}
+	}
+	return negative
+}
+
+// String возвращает текстовое представление ISO 8601
+// Нулевая продолжительность: P0D
+// Продолжительность с частями разных знаков выводится со знаком у каждой части,
+// например P1M-1D, такой формат не соответствует ISO 8601, но его принимает ParsePeriod
+func (p Period) String() string {
+	if p.IsZero() {
+		return "P0D"
+	}
+	b := &strings.Builder{}
+	if p.isNegative() {
+		b.WriteByte('-')
+		p = p.Negate()
+	}
+	b.WriteByte('P')
+	writePeriodPart(b, p.Years, 'Y')
+	writePeriodPart(b, p.Months, 'M')
+	writePeriodPart(b, p.Weeks, 'W')
+	writePeriodPart(b, p.Days, 'D')
+	if p.Hours == 0 && p.Minutes == 0 && p.Seconds == 0 && p.Nanoseconds == 0 {
+		return b.String()
+	}
+	b.WriteByte('T')
+	writePeriodPart(b, p.Hours, 'H')
+	writePeriodPart(b, p.Minutes, 'M')
+	switch {
+	case p.Nanoseconds != 0:
+		seconds := time.Duration(p.Seconds)*time.Second + time.Duration(p.Nanoseconds)
+		if seconds < 0 {
+			b.WriteByte('-')
+			seconds = -seconds
+		}
+		fmt.Fprintf(b, "%d", seconds/time.Second)
+		fraction := strings.TrimRight(fmt.Sprintf("%09d", seconds%time.Second), "0")
+		if fraction != "" {
+			b.WriteString("." + fraction)
+		}
+		b.WriteByte('S')
+	default:
+		writePeriodPart(b, p.Seconds, 'S')
+	}
+	return b.String()
+}
+
+// writePeriodPart выводит часть продолжительности, если она не нулевая
+func writePeriodPart(b *strings.Builder, value int, designator byte) {
+	if value == 0 {
+		return
+	}
+	b.WriteString(strconv.Itoa(value))
+	b.WriteByte(designator)
+}
+
+// AddPeriod возвращает t+period
+// Годы, месяцы, недели и дни добавляются в часовом поясе t,
+// если дня нет в полученном месяце - используется последний день месяца,
+// после этого добавляется точная часть
+func (t Time) AddPeriod(period Period) Time {
+	value, _ := t.AddDate(period.Years, period.Months, period.Weeks*7+period.Days)
+	return value.Add(period.Exact())
+}
+
+// setPeriodString устанавливает продолжительность из строки
+// Пустая строка соответствует нулевой продолжительности
+func (p *Period) setPeriodString(data string) error {
+	if data == "" {
+		*p = Period{}
+		return nil
+	}
+	period, err := ParsePeriod(data)
+	if err != nil {
+		return err
+	}
+	*p = period
+	return nil
+}
+
+// MarshalText это реализация интерфейса encoding.TextMarshaler
+func (p Period) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText это реализация интерфейса encoding.TextUnmarshaler
+func (p *Period) UnmarshalText(data []byte) error {
+	return p.setPeriodString(string(data))
+}
+
+// MarshalJSON необходим для кодирования продолжительности
+func (p Period) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON необходим для декодирования продолжительности
+func (p *Period) UnmarshalJSON(data []byte) error {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	return p.setPeriodString(value)
+}
+
+// MarshalXML необходим для кодирования продолжительности
+func (p Period) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(p.String(), start)
+}
+
+// UnmarshalXML необходим для декодирования продолжительности
+func (p *Period) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var data string
+	err := d.DecodeElement(&data, &start)
+	if err != nil {
+		return err
+	}
+	return p.setPeriodString(data)
+}
+
+// MarshalXMLAttr необходим для кодирования продолжительности
+func (p Period) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{
+		Name:  name,
+		Value: p.String(),
+	}, nil
+}
+
+// UnmarshalXMLAttr необходим для декодирования продолжительности
+func (p *Period) UnmarshalXMLAttr(attr xml.Attr) error {
+	return p.setPeriodString(attr.Value)
+}
+
+// Scan это реализация интерфейса database/sql.Scanner
+// Поддерживает PostgreSQL interval в стилях iso_8601 и postgres,
+// например «P1Y2M10DT2H30M» и «1 year 2 mons 10 days 02:30:00»
+func (p *Period) Scan(src interface{}) error {
+	var data string
+	switch v := src.(type) {
+	case string:
+		data = v
+	case []byte:
+		data = string(v)
+	default:
+		return fmt.Errorf("times: expected value type string or []byte but actual %T", src)
+	}
+	if strings.HasPrefix(data, "P") || strings.HasPrefix(data, "-P") {
+		return p.setPeriodString(data)
+	}
+	period, err := parsePostgresInterval(data)
+	if err != nil {
+		return err
+	}
+	*p = period
+	return nil
+}
+
+// Value это реализация database/sql/driver.Valuer
+// Возвращает строку ISO 8601, которую принимает PostgreSQL interval
+func (p Period) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// parsePostgresInterval разбирает PostgreSQL interval в стиле postgres
+// Пример: «1 year 2 mons -10 days -02:30:00.5»
+func parsePostgresInterval(s string) (Period, error) {
+	invalid := fmt.Errorf("times: cannot parse %q as interval", s)
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return Period{}, invalid
+	}
+	p := Period{}
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if strings.Contains(field, ":") {
+			clock, err := parsePostgresClock(field)
+			if err != nil {
+				return Period{}, invalid
+			}
+			p.Hours, p.Minutes, p.Seconds, p.Nanoseconds = clock.Hours, clock.Minutes, clock.Seconds, clock.Nanoseconds
+			continue
+		}
+		value, err := strconv.Atoi(field)
+		if err != nil || i+1 == len(fields) {
+			return Period{}, invalid
+		}
+		i++
+		switch strings.TrimSuffix(fields[i], "s") {
+		case "year":
+			p.Years = value
+		case "mon":
+			p.Months = value
+		case "day":
+			p.Days = value
+		default:
+			return Period{}, invalid
+		}
+	}
+	return p, nil
+}
+
+// parsePostgresClock разбирает [-+]hh:mm:ss[.ffffff]
+func parsePostgresClock(s string) (Period, error) {
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimLeft(s, "+-")
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return Period{}, fmt.Errorf("times: cannot parse %q as clock", s)
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return Period{}, err
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return Period{}, err
+	}
+	seconds, fraction := parts[2], ""
+	if i := strings.IndexByte(seconds, '.'); i >= 0 {
+		seconds, fraction = seconds[:i], seconds[i+1:]
+	}
+	p := Period{
+		Hours:   hours,
+		Minutes: minutes,
+	}
+	p.Seconds, err = strconv.Atoi(seconds)
+	if err != nil {
+		return Period{}, err
+	}
+	if fraction != "" {
+		if len(fraction) > 9 || !isDigits(fraction) {
+			return Period{}, fmt.Errorf("times: cannot parse %q as clock", s)
+		}
+		p.Nanoseconds, _ = strconv.Atoi(fraction + strings.Repeat("0", 9-len(fraction)))
+	}
+	if negative {
+		p = p.Negate()
+	}
+	return p, nil
+}
@@ -0,0 +1,138 @@
+package times
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPeriod(t *testing.T) {
+	Convey("Проверяем разбор продолжительности", t, func() {
+		testParsePeriod("P1Y2M10DT2H30M", Period{Years: 1, Months: 2, Days: 10, Hours: 2, Minutes: 30}, "")
+		testParsePeriod("-P1D", Period{Days: -1}, "")
+		testParsePeriod("P2W", Period{Weeks: 2}, "")
+		testParsePeriod("PT0.5S", Period{Nanoseconds: 500000000}, "")
+		testParsePeriod("PT1,25S", Period{Seconds: 1, Nanoseconds: 250000000}, "PT1.25S")
+		testParsePeriod("-PT1.5S", Period{Seconds: -1, Nanoseconds: -500000000}, "")
+		testParsePeriod("P1M", Period{Months: 1}, "")
+		testParsePeriod("PT1M", Period{Minutes: 1}, "")
+		testParsePeriod("PT0S", Period{}, "P0D")
+		testParsePeriod("P1M-1D", Period{Months: 1, Days: -1}, "")
+		testParsePeriod("P-1Y-2M3DT-4H-5M-6S", Period{Years: -1, Months: -2, Days: 3, Hours: -4, Minutes: -5, Seconds: -6}, "")
+		testParsePeriod("P-1Y-2M", Period{Years: -1, Months: -2}, "-P1Y2M")
+		testParsePeriod("PT1H-0.5S", Period{Hours: 1, Nanoseconds: -500000000}, "")
+		testParsePeriod("P+1D", Period{Days: 1}, "P1D")
+		Convey("Некорректные значения", func() {
+			for _, s := range []string{"", "P", "PT", "1D", "P1", "P1DT", "P1D1Y", "P1.5D", "PT1H1H", "P--1D", "P-D", "PT.5S", "PT1.S"} {
+				_, err := ParsePeriod(s)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+	Convey("Проверяем текстовое представление", t, func() {
+		So(Period{}.String(), ShouldEqual, "P0D")
+		So(Period{Months: 1, Days: -1}.String(), ShouldEqual, "P1M-1D")
+		So(Period{Hours: 36}.Exact(), ShouldEqual, 36*time.Hour)
+	})
+	Convey("Проверяем AddPeriod", t, func() {
+		testAddPeriod("2018-01-31T10:00:00", "P1M", "2018-02-28T10:00:00+03:00")
+		testAddPeriod("2018-01-31T10:00:00", "P1M1D", "2018-03-01T10:00:00+03:00")
+		testAddPeriod("2016-02-29T10:00:00", "P1Y", "2017-02-28T10:00:00+03:00")
+		testAddPeriod("2018-01-25T16:24:28", "P1Y2M10DT2H30M", "2019-04-04T18:54:28+03:00")
+		testAddPeriod("2018-01-25T16:24:28", "-P1W", "2018-01-18T16:24:28+03:00")
+		testAddPeriod("2018-01-25T23:00:00", "PT2H", "2018-01-26T01:00:00+03:00")
+		Convey("Переход на летнее время", func() {
+			location, err := time.LoadLocation("Europe/Berlin")
+			So(err, ShouldBeNil)
+			date := Time(time.Date(2018, time.March, 24, 12, 0, 0, 0, location))
+			So(date.AddPeriod(Period{Days: 1}).String(), ShouldEqual, "2018-03-25T12:00:00+02:00")
+			So(date.AddPeriod(Period{Hours: 24}).String(), ShouldEqual, "2018-03-25T13:00:00+02:00")
+		})
+	})
+	Convey("Проверяем кодирование", t, func() {
+		type Data struct {
+			XMLName xml.Name `xml:"a" json:"-"`
+			Attr    Period   `xml:"period,attr" json:"attr"`
+			Period  Period   `xml:"period" json:"period"`
+		}
+		data := Data{
+			Attr:   Period{Months: 1},
+			Period: Period{Hours: 2, Minutes: 30},
+		}
+		result, err := json.Marshal(data)
+		So(err, ShouldBeNil)
+		So(string(result), ShouldEqual, `{"attr":"P1M","period":"PT2H30M"}`)
+
+		result, err = xml.Marshal(data)
+		So(err, ShouldBeNil)
+		So(string(result), ShouldEqual, `<a period="P1M"><period>PT2H30M</period></a>`)
+
+		var decoded Data
+		err = xml.Unmarshal(result, &decoded)
+		So(err, ShouldBeNil)
+		So(decoded.Attr, ShouldResemble, data.Attr)
+		So(decoded.Period, ShouldResemble, data.Period)
+	})
+	Convey("Проверяем database/sql", t, func() {
+		var p Period
+		So(p.Scan("P1Y2M10DT2H30M"), ShouldBeNil)
+		So(p, ShouldResemble, Period{Years: 1, Months: 2, Days: 10, Hours: 2, Minutes: 30})
+
+		So(p.Scan([]byte("1 year 2 mons -10 days -02:30:00.5")), ShouldBeNil)
+		So(p, ShouldResemble, Period{Years: 1, Months: 2, Days: -10, Hours: -2, Minutes: -30, Nanoseconds: -500000000})
+
+		So(p.Scan("3 days"), ShouldBeNil)
+		So(p, ShouldResemble, Period{Days: 3})
+
+		Convey("Части разных знаков", func() {
+			for _, source := range []string{"P-1Y-2M3DT-4H-5M-6S", "1 mon -1 days", "-1 years -2 mons", "-P1Y2M"} {
+				var scanned Period
+				So(scanned.Scan(source), ShouldBeNil)
+				data, err := json.Marshal(scanned)
+				So(err, ShouldBeNil)
+				var decoded Period
+				So(json.Unmarshal(data, &decoded), ShouldBeNil)
+				So(decoded, ShouldResemble, scanned)
+
+				value, err := scanned.Value()
+				So(err, ShouldBeNil)
+				So(decoded.Scan(value), ShouldBeNil)
+				So(decoded, ShouldResemble, scanned)
+			}
+			So(p.Scan("P-1Y-2M3DT-4H-5M-6S"), ShouldBeNil)
+			So(p, ShouldResemble, Period{Years: -1, Months: -2, Days: 3, Hours: -4, Minutes: -5, Seconds: -6})
+		})
+
+		So(p.Scan("1 fortnight"), ShouldNotBeNil)
+		So(p.Scan(1), ShouldNotBeNil)
+
+		value, err := Period{Days: 3}.Value()
+		So(err, ShouldBeNil)
+		So(value, ShouldEqual, "P3D")
+	})
+}
+
+func testParsePeriod(source string, expected Period, formatted string) {
+	Convey(source, func() {
+		result, err := ParsePeriod(source)
+		So(err, ShouldBeNil)
+		So(result, ShouldResemble, expected)
+		if formatted == "" {
+			formatted = source
+		}
+		So(result.String(), ShouldEqual, formatted)
+	})
+}
+
+func testAddPeriod(start, period, expected string) {
+	Convey(start+" + "+period, func() {
+		date, err := NewMoscowTimeString(start)
+		So(err, ShouldBeNil)
+		p, err := ParsePeriod(period)
+		So(err, ShouldBeNil)
+		So(date.AddPeriod(p).String(), ShouldEqual, expected)
+	})
+}
@@ -0,0 +1,97 @@
+package times
+
+import (
+	"strings"
+)
+
+// Precision это точность долей секунды при кодировании
+type Precision struct {
+	// Digits это количество знаков долей секунды от 0 до 9
+	Digits int
+
+	// Trim убирает завершающие нули долей секунды,
+	// иначе выводится ровно Digits знаков
+	Trim bool
+}
+
+var (
+	// PrecisionSeconds без долей секунды
+	PrecisionSeconds = Precision{}
+
+	// PrecisionMillis миллисекунды, .000
+	PrecisionMillis = Precision{Digits: 3}
+
+	// PrecisionMicros микросекунды, .000000
+	PrecisionMicros = Precision{Digits: 6}
+
+	// PrecisionNanos наносекунды, .000000000
+	PrecisionNanos = Precision{Digits: 9}
+
+	// PrecisionAsParsed значащие доли секунды, .999999999
+	// Для входных данных без завершающих нулей совпадает с исходной точностью,
+	// точное количество знаков сохраняет OffsetTime
+	PrecisionAsParsed = Precision{Digits: 9, Trim: true}
+)
+
+// PrecisionZone это необязательное расширение Zone,
+// задающее точность долей секунды для Zoned и NullZoned
+// Применяется к Zone.Layout() и String()
+type PrecisionZone interface {
+	Zone
+
+	// Precision возвращает точность долей секунды
+	Precision() Precision
+}
+
+// Trimmed возвращает точность с удалением завершающих нулей
+func (p Precision) Trimmed() Precision {
+	p.Trim = true
+	return p
+}
+
+// Layout возвращает layout с долями секунды заданной точности
+// Существующие доли секунды в layout заменяются, layout без секунд не изменяется
+func (p Precision) Layout(layout string) string {
+	i := strings.Index(layout, "05")
+	if i < 0 {
+		return layout
+	}
+	end := i + 2
+	if end < len(layout) && (layout[end] == '.' || layout[end] == ',') {
+		j := end + 1
+		for j < len(layout) && (layout[j] == '0' || layout[j] == '9') {
+			j++
+		}
+		if j > end+1 {
+			layout = layout[:end] + layout[j:]
+		}
+	}
+	digits := p.Digits
+	if digits <= 0 {
+		return layout
+	}
+	if digits > 9 {
+		digits = 9
+	}
+	digit := "0"
+	if p.Trim {
+		digit = "9"
+	}
+	return layout[:end] + "." + strings.Repeat(digit, digits) + layout[end:]
+}
+
+// withPrecision возвращает layout с первой заданной точностью
+func withPrecision(layout string, precision []Precision) string {
+	if len(precision) == 0 {
+		return layout
+	}
+	return precision[0].Layout(layout)
+}
+
+// zoneLayout возвращает формат кодирования zone с учётом PrecisionZone
+func zoneLayout(zone Zone, layout string) string {
+	if p, ok := zone.(PrecisionZone); ok {
+		return p.Precision().Layout(layout)
+	}
+	return layout
+}
@@ -0,0 +1,78 @@
+package times
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// millisMoscow это Moscow с миллисекундами при кодировании
+type millisMoscow struct {
+	Moscow
+}
+
+func (millisMoscow) Precision() Precision {
+	return PrecisionMillis
+}
+
+func TestPrecision(t *testing.T) {
+	Convey("Проверяем изменение формата", t, func() {
+		layout := "2006-01-02T15:04:05Z07:00"
+		So(PrecisionSeconds.Layout(layout), ShouldEqual, layout)
+		So(PrecisionMillis.Layout(layout), ShouldEqual, "2006-01-02T15:04:05.000Z07:00")
+		So(PrecisionMicros.Trimmed().Layout(layout), ShouldEqual, "2006-01-02T15:04:05.999999Z07:00")
+		So(PrecisionNanos.Layout(layout), ShouldEqual, "2006-01-02T15:04:05.000000000Z07:00")
+		So(PrecisionAsParsed.Layout(layout), ShouldEqual, "2006-01-02T15:04:05.999999999Z07:00")
+		So(PrecisionMillis.Layout("2006-01-02T15:04:05.999999999Z07:00"), ShouldEqual, "2006-01-02T15:04:05.000Z07:00")
+		So(PrecisionSeconds.Layout("2006-01-02T15:04:05.000"), ShouldEqual, "2006-01-02T15:04:05")
+		So(PrecisionMillis.Layout("2006-01-02"), ShouldEqual, "2006-01-02")
+	})
+	Convey("Проверяем Custom* методы", t, func() {
+		date := Time(time.Date(2018, time.January, 25, 16, 24, 28, 740000000, time.UTC))
+		layout := "2006-01-02T15:04:05Z07:00"
+
+		data, err := date.CustomMarshalJSON(time.UTC, layout, PrecisionMillis)
+		So(err, ShouldBeNil)
+		So(string(data), ShouldEqual, `"2018-01-25T16:24:28.740Z"`)
+
+		data, err = date.CustomMarshalText(time.UTC, layout, PrecisionAsParsed)
+		So(err, ShouldBeNil)
+		So(string(data), ShouldEqual, `2018-01-25T16:24:28.74Z`)
+
+		attr, err := date.CustomMarshalXMLAttr(xml.Name{Local: "date"}, time.UTC, layout, PrecisionMicros)
+		So(err, ShouldBeNil)
+		So(attr.Value, ShouldEqual, `2018-01-25T16:24:28.740000Z`)
+
+		data, err = date.MarshalJSON()
+		So(err, ShouldBeNil)
+		So(string(data), ShouldEqual, `"2018-01-25T16:24:28Z"`)
+	})
+	Convey("Проверяем PrecisionZone", t, func() {
+		type Data struct {
+			XMLName  xml.Name                `xml:"a" json:"-"`
+			DateAttr Zoned[millisMoscow]     `xml:"date,attr" json:"start"`
+			Date     NullZoned[millisMoscow] `xml:"date" json:"date"`
+		}
+		source := `{"start":"2018-01-25T16:24:28.74","date":"2018-01-25T16:24:28.5+05:00"}`
+		var data Data
+		err := json.Unmarshal([]byte(source), &data)
+		So(err, ShouldBeNil)
+		So(data.DateAttr.String(), ShouldEqual, "2018-01-25T16:24:28.740+03:00")
+		So(data.Date.String(), ShouldEqual, "2018-01-25T14:24:28.500+03:00")
+
+		result, err := json.Marshal(data)
+		So(err, ShouldBeNil)
+		So(string(result), ShouldEqual, `{"start":"2018-01-25T13:24:28.740Z","date":"2018-01-25T11:24:28.500Z"}`)
+
+		result, err = xml.Marshal(data)
+		So(err, ShouldBeNil)
+		So(string(result), ShouldEqual, `<a date="2018-01-25T13:24:28.740Z"><date>2018-01-25T11:24:28.500Z</date></a>`)
+
+		result, err = data.DateAttr.MarshalText()
+		So(err, ShouldBeNil)
+		So(string(result), ShouldEqual, `2018-01-25T13:24:28.740Z`)
+	})
+}
@@ -0,0 +1,363 @@
+package times
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Unbounded это количество повторений бесконечной последовательности
+const Unbounded = -1
+
+// Recurrence это повторяющийся интервал ISO 8601
+//
+// Формат: Rn/<интервал>, где n - количество повторений, без n - бесконечно
+// Пример:
+//   «R12/2018-01-01T00:00:00+03:00/P1M» - «12 раз каждый месяц начиная с 1 января 2018 года»
+//   «R/2018-01-01T00:00:00/PT1H»        - «каждый час начиная с 1 января 2018 года»
+//   «R3/P1D/2018-01-10T00:00:00»        - «3 дня, последний заканчивается 10 января 2018 года»
+//
+// Повторение k вычисляется от начала интервала как start+k*period,
+// поэтому 31 января + P1M даёт 28 февраля, 31 марта, 30 апреля и т.д.
+// Для записи начало/конец шаг равен точной длительности интервала
+type Recurrence struct {
+	// Repetitions это количество повторений или Unbounded
+	Repetitions int
+
+	// Interval это первый интервал последовательности
+	Interval Interval
+}
+
+// ParseRecurrence возвращает повторяющийся интервал на основе строки ISO 8601
+// Необязательный parser заменяет DefaultParser
+func ParseRecurrence(s string, location *time.Location, parser ...*Parser) (*Recurrence, error) {
+	i := strings.Index(s, "/")
+	if !strings.HasPrefix(s, "R") || i < 0 {
+		return nil, fmt.Errorf("times: cannot parse %q as recurrence", s)
+	}
+	repetitions := Unbounded
+	if count := s[1:i]; count != "" && count != "-1" {
+		n, err := strconv.Atoi(count)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("times: cannot parse %q as recurrence", s)
+		}
+		repetitions = n
+	}
+	interval, err := ParseInterval(s[i+1:], location, parser...)
+	if err != nil {
+		return nil, err
+	}
+	if !interval.IsAnchored() {
+		return nil, fmt.Errorf("times: recurrence %q has no start or end", s)
+	}
+	if interval.form == intervalPeriodEnd && repetitions == Unbounded {
+		return nil, fmt.Errorf("times: unbounded recurrence %q has no start", s)
+	}
+	return &Recurrence{
+		Repetitions: repetitions,
+		Interval:    *interval,
+	}, nil
+}
+
+// IsZero возвращает true для нулевого значения, которое кодируется как пустое значение
+func (r Recurrence) IsZero() bool {
+	return r.Repetitions == 0 && r.Interval.IsZero()
+}
+
+// IsUnbounded возвращает true для бесконечной последовательности
+func (r Recurrence) IsUnbounded() bool {
+	return r.Repetitions == Unbounded
+}
+
+// Occurrence возвращает интервал повторения k, начиная с 0
+// Для записи продолжительность/конец последним является повторение Repetitions-1
+func (r Recurrence) Occurrence(k int) Interval {
+	i := r.Interval
+	switch i.form {
+	case intervalStartPeriod:
+		return Interval{
+			Start: i.Start.AddPeriod(scalePeriod(i.Period, k)),
+			End:   i.Start.AddPeriod(scalePeriod(i.Period, k+1)),
+		}
+	case intervalPeriodEnd:
+		return Interval{
+			Start: i.End.AddPeriod(scalePeriod(i.Period, k-r.Repetitions)),
+			End:   i.End.AddPeriod(scalePeriod(i.Period, k+1-r.Repetitions)),
+		}
+	}
+	step := i.Duration()
+	return Interval{
+		Start: i.Start.Add(step * time.Duration(k)),
+		End:   i.Start.Add(step * time.Duration(k+1)),
+	}
+}
+
+// Iterator возвращает итератор начал повторений в location
+func (r Recurrence) Iterator(location *time.Location) (*RecurrenceIterator, error) {
+	if location == nil {
+		return nil, errors.New("times: empty time location")
+	}
+	return &RecurrenceIterator{
+		recurrence: r,
+		location:   location,
+	}, nil
+}
+
+// String возвращает текстовое представление
+func (r Recurrence) String() string {
+	return r.prefix() + r.Interval.String()
+}
+
+// Format возвращает текстовое представление,
+// время приводится к location и форматируется в format
+func (r Recurrence) Format(location *time.Location, format string) string {
+	return r.prefix() + r.Interval.Format(location, format)
+}
+
+// prefix возвращает Rn/
+func (r Recurrence) prefix() string {
+	if r.IsUnbounded() {
+		return "R/"
+	}
+	return "R" + strconv.Itoa(r.Repetitions) + "/"
+}
+
+// RecurrenceIterator это итератор начал повторений
+type RecurrenceIterator struct {
+	recurrence Recurrence
+	location   *time.Location
+	next       int
+}
+
+// Next возвращает начало следующего повторения
+// Второе значение false если повторения закончились
+func (it *RecurrenceIterator) Next() (Time, bool) {
+	if !it.recurrence.IsUnbounded() && it.next >= it.recurrence.Repetitions {
+		return Time{}, false
+	}
+	occurrence := it.recurrence.Occurrence(it.next)
+	it.next++
+	return Time(occurrence.Start.Time().In(it.location)), true
+}
+
+// Take возвращает не более n следующих начал повторений
+func (it *RecurrenceIterator) Take(n int) []Time {
+	result := make([]Time, 0, n)
+	for len(result) < n {
+		t, ok := it.Next()
+		if !ok {
+			break
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+// scalePeriod возвращает period*n
+func scalePeriod(period Period, n int) Period {
+	return Period{
+		Years:       period.Years * n,
+		Months:      period.Months * n,
+		Weeks:       period.Weeks * n,
+		Days:        period.Days * n,
+		Hours:       period.Hours * n,
+		Minutes:     period.Minutes * n,
+		Seconds:     period.Seconds * n,
+		Nanoseconds: period.Nanoseconds * n,
+	}
+}
+
+// setRecurrenceString устанавливает повторяющийся интервал из строки
+// Пустая строка соответствует нулевому значению
+func (r *Recurrence) setRecurrenceString(data string, location *time.Location, parser *Parser) error {
+	if data == "" {
+		*r = Recurrence{}
+		return nil
+	}
+	recurrence, err := ParseRecurrence(data, location, parser)
+	if err != nil {
+		return err
+	}
+	*r = *recurrence
+	return nil
+}
+
+// MarshalText это реализация интерфейса encoding.TextMarshaler
+func (r Recurrence) MarshalText() ([]byte, error) {
+	return r.CustomMarshalText(time.UTC, "2006-01-02T15:04:05Z07:00")
+}
+
+// CustomMarshalText это реализация интерфейса encoding.TextMarshaler
+func (r Recurrence) CustomMarshalText(location *time.Location, format string) ([]byte, error) {
+	if location == nil {
+		return []byte{}, errors.New("times: empty time location")
+	}
+	if r.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(r.Format(location, format)), nil
+}
+
+// UnmarshalText это реализация интерфейса encoding.TextUnmarshaler
+func (r *Recurrence) UnmarshalText(data []byte) error {
+	return r.CustomUnmarshalText(data, time.UTC)
+}
+
+// CustomUnmarshalText это реализация интерфейса encoding.TextUnmarshaler
+// Необязательный parser заменяет DefaultParser
+func (r *Recurrence) CustomUnmarshalText(data []byte, location *time.Location, parser ...*Parser) error {
+	return r.setRecurrenceString(string(data), location, getParser(parser))
+}
+
+// MarshalJSON необходим для кодирования повторяющегося интервала
+func (r Recurrence) MarshalJSON() ([]byte, error) {
+	return r.CustomMarshalJSON(time.UTC, "2006-01-02T15:04:05Z07:00")
+}
+
+// CustomMarshalJSON необходим для кодирования повторяющегося интервала
+func (r Recurrence) CustomMarshalJSON(location *time.Location, format string) ([]byte, error) {
+	if location == nil {
+		return []byte{}, errors.New("times: empty time location")
+	}
+	if r.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(r.Format(location, format))
+}
+
+// UnmarshalJSON необходим для декодирования повторяющегося интервала
+func (r *Recurrence) UnmarshalJSON(data []byte) error {
+	return r.CustomUnmarshalJSON(data, time.UTC)
+}
+
+// CustomUnmarshalJSON необходим для декодирования повторяющегося интервала
+// JSON null и пустая строка соответствуют нулевому значению
+// Необязательный parser заменяет DefaultParser
+func (r *Recurrence) CustomUnmarshalJSON(data []byte, location *time.Location, parser ...*Parser) error {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	return r.setRecurrenceString(value, location, getParser(parser))
+}
+
+// MarshalXML необходим для кодирования повторяющегося интервала
+func (r Recurrence) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return r.CustomMarshalXML(e, start, time.UTC, "2006-01-02T15:04:05Z07:00")
+}
+
+// CustomMarshalXML необходим для кодирования повторяющегося интервала
+func (r Recurrence) CustomMarshalXML(
+	e *xml.Encoder,
+	start xml.StartElement,
+	location *time.Location,
+	format string,
+) error {
+	if location == nil {
+		return errors.New("times: empty time location")
+	}
+	if r.IsZero() {
+		return e.EncodeElement("", start)
+	}
+	return e.EncodeElement(r.Format(location, format), start)
+}
+
+// UnmarshalXML необходим для декодирования повторяющегося интервала
+func (r *Recurrence) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return r.CustomUnmarshalXML(d, start, time.UTC)
+}
+
+// CustomUnmarshalXML необходим для декодирования повторяющегося интервала
+// Необязательный parser заменяет DefaultParser
+func (r *Recurrence) CustomUnmarshalXML(
+	d *xml.Decoder,
+	start xml.StartElement,
+	location *time.Location,
+	parser ...*Parser,
+) error {
+	var data string
+	err := d.DecodeElement(&data, &start)
+	if err != nil {
+		return err
+	}
+	return withField(
+		r.setRecurrenceString(data, location, getParser(parser)),
+		start.Name.Local,
+	)
+}
+
+// MarshalXMLAttr необходим для кодирования повторяющегося интервала
+func (r Recurrence) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return r.CustomMarshalXMLAttr(name, time.UTC, "2006-01-02T15:04:05Z07:00")
+}
+
+// CustomMarshalXMLAttr необходим для кодирования повторяющегося интервала
+func (r Recurrence) CustomMarshalXMLAttr(
+	name xml.Name,
+	location *time.Location,
+	format string,
+) (
+	xml.Attr,
+	error,
+) {
+	if location == nil {
+		return xml.Attr{}, errors.New("times: empty time location")
+	}
+	if r.IsZero() {
+		return xml.Attr{}, nil
+	}
+	return xml.Attr{
+		Name:  name,
+		Value: r.Format(location, format),
+	}, nil
+}
+
+// UnmarshalXMLAttr необходим для декодирования повторяющегося интервала
+func (r *Recurrence) UnmarshalXMLAttr(attr xml.Attr) error {
+	return r.CustomUnmarshalXMLAttr(attr, time.UTC)
+}
+
+// CustomUnmarshalXMLAttr необходим для декодирования повторяющегося интервала
+// Необязательный parser заменяет DefaultParser
+func (r *Recurrence) CustomUnmarshalXMLAttr(attr xml.Attr, location *time.Location, parser ...*Parser) error {
+	return withField(
+		r.setRecurrenceString(attr.Value, location, getParser(parser)),
+		attr.Name.Local,
+	)
+}
+
+// Scan это реализация интерфейса database/sql.Scanner
+// NULL соответствует нулевому значению
+func (r *Recurrence) Scan(src interface{}) error {
+	return r.CustomScan(src, time.UTC)
+}
+
+// CustomScan это реализация интерфейса database/sql.Scanner
+// Необязательный parser заменяет DefaultParser
+func (r *Recurrence) CustomScan(src interface{}, location *time.Location, parser ...*Parser) error {
+	switch v := src.(type) {
+	case nil:
+		*r = Recurrence{}
+		return nil
+	case string:
+		return r.setRecurrenceString(v, location, getParser(parser))
+	case []byte:
+		return r.setRecurrenceString(string(v), location, getParser(parser))
+	}
+	return fmt.Errorf("times: expected value type string or []byte but actual %T", src)
+}
+
+// Value это реализация database/sql/driver.Valuer
+// Для нулевого значения возвращает nil
+func (r Recurrence) Value() (driver.Value, error) {
+	if r.IsZero() {
+		return nil, nil
+	}
+	return r.Format(time.UTC, "2006-01-02T15:04:05Z07:00"), nil
+}
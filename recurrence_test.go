@@ -0,0 +1,153 @@
+package times
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRecurrence(t *testing.T) {
+	Convey("Проверяем повторения", t, func() {
+		testRecurrence(
+			"R4/2018-01-31T00:00:00+03:00/P1M",
+			10,
+			"2018-01-31T00:00:00+03:00",
+			"2018-02-28T00:00:00+03:00",
+			"2018-03-31T00:00:00+03:00",
+			"2018-04-30T00:00:00+03:00",
+		)
+		testRecurrence(
+			"R/2018-01-01T00:00:00/PT12H",
+			3,
+			"2018-01-01T00:00:00+03:00",
+			"2018-01-01T12:00:00+03:00",
+			"2018-01-02T00:00:00+03:00",
+		)
+		testRecurrence(
+			"R3/2018-01-01T00:00:00/2018-01-01T08:00:00",
+			10,
+			"2018-01-01T00:00:00+03:00",
+			"2018-01-01T08:00:00+03:00",
+			"2018-01-01T16:00:00+03:00",
+		)
+		testRecurrence(
+			"R3/P1D/2018-01-10T00:00:00",
+			10,
+			"2018-01-07T00:00:00+03:00",
+			"2018-01-08T00:00:00+03:00",
+			"2018-01-09T00:00:00+03:00",
+		)
+		testRecurrence("R0/2018-01-01T00:00:00/P1D", 10)
+		Convey("Некорректные значения", func() {
+			for _, s := range []string{"2018-01-01T00:00:00/P1D", "R/P1D", "Rx/2018-01-01T00:00:00/P1D", "R-2/2018-01-01T00:00:00/P1D", "R/P1D/2018-01-10T00:00:00"} {
+				_, err := ParseRecurrence(s, MoscowLocation)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+	Convey("Проверяем итератор в другом часовом поясе", t, func() {
+		recurrence, err := ParseRecurrence("R2/2018-01-01T00:00:00+03:00/P1D", MoscowLocation)
+		So(err, ShouldBeNil)
+		So(recurrence.String(), ShouldEqual, "R2/2018-01-01T00:00:00+03:00/P1D")
+
+		it, err := recurrence.Iterator(time.UTC)
+		So(err, ShouldBeNil)
+		first, ok := it.Next()
+		So(ok, ShouldBeTrue)
+		So(first.String(), ShouldEqual, "2017-12-31T21:00:00Z")
+
+		_, err = recurrence.Iterator(nil)
+		So(err, ShouldNotBeNil)
+	})
+	Convey("Проверяем кодирование", t, func() {
+		type Data struct {
+			XMLName  xml.Name   `xml:"a" json:"-"`
+			Schedule Recurrence `xml:"schedule,attr" json:"schedule"`
+			Billing  Recurrence `xml:"billing" json:"billing"`
+		}
+		source := `{"schedule":"R/2018-01-01T00:00:00Z/PT1H","billing":"R12/2018-01-01T00:00:00Z/P1M"}`
+		var data Data
+		err := json.Unmarshal([]byte(source), &data)
+		So(err, ShouldBeNil)
+		So(data.Schedule.IsUnbounded(), ShouldBeTrue)
+		So(data.Billing.Repetitions, ShouldEqual, 12)
+
+		result, err := json.Marshal(data)
+		So(err, ShouldBeNil)
+		So(string(result), ShouldEqual, source)
+
+		result, err = xml.Marshal(data)
+		So(err, ShouldBeNil)
+		So(string(result), ShouldEqual, `<a schedule="R/2018-01-01T00:00:00Z/PT1H"><billing>R12/2018-01-01T00:00:00Z/P1M</billing></a>`)
+
+		var decoded Data
+		err = xml.Unmarshal(result, &decoded)
+		So(err, ShouldBeNil)
+		So(decoded.Billing.Format(time.UTC, "2006-01-02T15:04:05Z07:00"), ShouldEqual, "R12/2018-01-01T00:00:00Z/P1M")
+	})
+	Convey("Проверяем кодирование нулевого значения", t, func() {
+		type Data struct {
+			XMLName  xml.Name   `xml:"a" json:"-"`
+			Schedule Recurrence `xml:"schedule,attr" json:"schedule"`
+			Billing  Recurrence `xml:"billing" json:"billing"`
+		}
+		So(Recurrence{}.IsZero(), ShouldBeTrue)
+		So(Recurrence{Repetitions: Unbounded}.IsZero(), ShouldBeFalse)
+
+		result, err := json.Marshal(Data{})
+		So(err, ShouldBeNil)
+		So(string(result), ShouldEqual, `{"schedule":null,"billing":null}`)
+
+		data := Data{Schedule: Recurrence{Repetitions: 1}}
+		So(json.Unmarshal(result, &data), ShouldBeNil)
+		So(data.Schedule.IsZero(), ShouldBeTrue)
+		So(json.Unmarshal([]byte(`{"schedule":"","billing":""}`), &data), ShouldBeNil)
+		So(data.Billing.IsZero(), ShouldBeTrue)
+
+		result, err = xml.Marshal(Data{})
+		So(err, ShouldBeNil)
+		So(string(result), ShouldEqual, `<a><billing></billing></a>`)
+		So(xml.Unmarshal(result, &data), ShouldBeNil)
+		So(data.Billing.IsZero(), ShouldBeTrue)
+
+		text, err := Recurrence{}.MarshalText()
+		So(err, ShouldBeNil)
+		So(string(text), ShouldEqual, "")
+	})
+	Convey("Проверяем database/sql", t, func() {
+		var recurrence Recurrence
+		So(recurrence.Scan("R12/2018-01-01T00:00:00Z/P1M"), ShouldBeNil)
+		So(recurrence.Repetitions, ShouldEqual, 12)
+
+		value, err := recurrence.Value()
+		So(err, ShouldBeNil)
+		So(value, ShouldEqual, "R12/2018-01-01T00:00:00Z/P1M")
+
+		So(recurrence.Scan(nil), ShouldBeNil)
+		So(recurrence.IsZero(), ShouldBeTrue)
+		value, err = recurrence.Value()
+		So(err, ShouldBeNil)
+		So(value, ShouldBeNil)
+
+		So(recurrence.Scan([]byte("R/2018-01-01T00:00:00Z/PT1H")), ShouldBeNil)
+		So(recurrence.IsUnbounded(), ShouldBeTrue)
+		So(recurrence.Scan(1), ShouldNotBeNil)
+	})
+}
+
+func testRecurrence(source string, n int, expected ...string) {
+	Convey(source, func() {
+		recurrence, err := ParseRecurrence(source, MoscowLocation)
+		So(err, ShouldBeNil)
+		it, err := recurrence.Iterator(MoscowLocation)
+		So(err, ShouldBeNil)
+		result := []string{}
+		for _, t := range it.Take(n) {
+			result = append(result, t.String())
+		}
+		So(result, ShouldResemble, append([]string{}, expected...))
+	})
+}
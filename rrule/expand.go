@@ -0,0 +1,408 @@
+package rrule
+
+import (
+	"sort"
+	"time"
+)
+
+// maxYear это год, после которого повторения не вычисляются
+const maxYear = 9999
+
+// ruleIterator последовательно вычисляет повторения правила
+// Повторения вычисляются по периодам частоты FREQ
+type ruleIterator struct {
+	rule     Rule
+	location *time.Location
+	start    time.Time
+	period   int
+	buffer   []time.Time
+	count    int
+	done     bool
+}
+
+// newRuleIterator возвращает итератор повторений правила
+func newRuleIterator(r Rule) *ruleIterator {
+	if r.Interval < 1 {
+		r.Interval = 1
+	}
+	location := r.Dtstart.Location()
+	return &ruleIterator{
+		rule:     r,
+		location: location,
+		start:    r.Dtstart,
+	}
+}
+
+// next возвращает следующее повторение
+func (it *ruleIterator) next() (time.Time, bool) {
+	for !it.done {
+		for len(it.buffer) > 0 {
+			t := it.buffer[0]
+			it.buffer = it.buffer[1:]
+			if t.Before(it.start) {
+				continue
+			}
+			if !it.rule.Until.IsZero() && t.After(it.rule.Until) {
+				it.done = true
+				return time.Time{}, false
+			}
+			it.count++
+			if it.rule.Count > 0 && it.count >= it.rule.Count {
+				it.done = true
+			}
+			return t, true
+		}
+		it.buffer = it.nextPeriod()
+	}
+	return time.Time{}, false
+}
+
+// nextPeriod возвращает отсортированные повторения следующего периода
+func (it *ruleIterator) nextPeriod() []time.Time {
+	r := it.rule
+	if r.Freq >= Hourly {
+		return it.nextClockPeriod()
+	}
+	start := it.start
+	var first, last time.Time
+	year, month, day := start.Date()
+	switch r.Freq {
+	case Yearly:
+		first = date(year+it.period*r.Interval, time.January, 1)
+		last = first.AddDate(1, 0, -1)
+	case Monthly:
+		first = date(year, month+time.Month(it.period*r.Interval), 1)
+		last = first.AddDate(0, 1, -1)
+	case Weekly:
+		offset := (int(start.Weekday()) - int(r.WeekStart) + 7) % 7
+		first = date(year, month, day-offset+it.period*r.Interval*7)
+		last = first.AddDate(0, 0, 6)
+	default:
+		first = date(year, month, day+it.period*r.Interval)
+		last = first
+	}
+	it.period++
+	if first.Year() > maxYear {
+		it.done = true
+		return nil
+	}
+
+	var result []time.Time
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		if !it.matchDay(d) {
+			continue
+		}
+		for _, hour := range it.hours() {
+			for _, minute := range it.minutes() {
+				for _, second := range it.seconds() {
+					y, m, dd := d.Date()
+					result = append(result, wallTime(y, m, dd, hour, minute, second, start.Nanosecond(), it.location))
+				}
+			}
+		}
+	}
+	return setPos(result, r.BySetPos)
+}
+
+// nextClockPeriod возвращает повторения следующего часа, минуты или секунды
+// Периоды отсчитываются от Dtstart в точном времени,
+// поэтому при переходе на летнее и зимнее время не пропускаются и не дублируются
+func (it *ruleIterator) nextClockPeriod() []time.Time {
+	r := it.rule
+	var unit time.Duration
+	switch r.Freq {
+	case Hourly:
+		unit = time.Hour
+	case Minutely:
+		unit = time.Minute
+	default:
+		unit = time.Second
+	}
+	step := unit * time.Duration(r.Interval)
+	for {
+		t := it.start.Add(step * time.Duration(it.period)).In(it.location)
+		if t.Year() > maxYear {
+			it.done = true
+			return nil
+		}
+		y, m, d := t.Date()
+		if !it.matchDay(date(y, m, d)) {
+			it.skipTo(wallTime(y, m, d+1, 0, 0, 0, 0, it.location), step)
+			continue
+		}
+		if len(r.ByHour) > 0 && !contains(r.ByHour, t.Hour()) {
+			it.skipTo(t.Add(time.Hour-sinceHour(t)), step)
+			continue
+		}
+		if r.Freq >= Minutely && len(r.ByMinute) > 0 && !contains(r.ByMinute, t.Minute()) {
+			it.skipTo(t.Truncate(time.Minute).Add(time.Minute), step)
+			continue
+		}
+		if r.Freq == Secondly && len(r.BySecond) > 0 && !contains(r.BySecond, t.Second()) {
+			it.period++
+			continue
+		}
+		it.period++
+
+		var result []time.Time
+		switch r.Freq {
+		case Hourly:
+			hour := t.Add(-sinceHour(t) + time.Duration(t.Nanosecond()))
+			for _, minute := range it.minutes() {
+				for _, second := range it.seconds() {
+					result = append(result, hour.Add(time.Duration(minute)*time.Minute+time.Duration(second)*time.Second))
+				}
+			}
+		case Minutely:
+			minute := t.Add(-time.Duration(t.Second()) * time.Second)
+			for _, second := range it.seconds() {
+				result = append(result, minute.Add(time.Duration(second)*time.Second))
+			}
+		default:
+			result = append(result, t)
+		}
+		sortTimes(result)
+		return setPos(result, r.BySetPos)
+	}
+}
+
+// skipTo переходит к первому периоду не раньше t
+func (it *ruleIterator) skipTo(t time.Time, step time.Duration) {
+	elapsed := t.Sub(it.start)
+	period := int((elapsed + step - 1) / step)
+	if period <= it.period {
+		period = it.period + 1
+	}
+	it.period = period
+}
+
+// sinceHour возвращает время, прошедшее с начала часа на часах t
+func sinceHour(t time.Time) time.Duration {
+	return time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second +
+		time.Duration(t.Nanosecond())
+}
+
+// hours возвращает часы повторений внутри дня
+func (it *ruleIterator) hours() []int {
+	if len(it.rule.ByHour) > 0 {
+		return sorted(it.rule.ByHour)
+	}
+	return []int{it.start.Hour()}
+}
+
+// minutes возвращает минуты повторений внутри часа
+func (it *ruleIterator) minutes() []int {
+	if len(it.rule.ByMinute) > 0 {
+		return sorted(it.rule.ByMinute)
+	}
+	return []int{it.start.Minute()}
+}
+
+// seconds возвращает секунды повторений внутри минуты
+func (it *ruleIterator) seconds() []int {
+	if len(it.rule.BySecond) > 0 {
+		return sorted(it.rule.BySecond)
+	}
+	return []int{it.start.Second()}
+}
+
+// matchDay возвращает true если день d (полночь в UTC) подходит правилу
+func (it *ruleIterator) matchDay(d time.Time) bool {
+	r := it.rule
+	year, month, day := d.Date()
+
+	byMonthDay := r.ByMonthDay
+	byDay := r.ByDay
+	if len(r.ByWeekNo) == 0 && len(r.ByYearDay) == 0 && len(byMonthDay) == 0 && len(byDay) == 0 {
+		switch r.Freq {
+		case Yearly:
+			if len(r.ByMonth) == 0 && month != it.start.Month() {
+				return false
+			}
+			byMonthDay = []int{it.start.Day()}
+		case Monthly:
+			byMonthDay = []int{it.start.Day()}
+		case Weekly:
+			byDay = []Weekday{{Day: it.start.Weekday()}}
+		}
+	}
+
+	if len(r.ByMonth) > 0 && !contains(r.ByMonth, int(month)) {
+		return false
+	}
+	if len(r.ByWeekNo) > 0 && !matchWeekNo(d, r.WeekStart, r.ByWeekNo) {
+		return false
+	}
+	if len(r.ByYearDay) > 0 {
+		days := daysInYear(year)
+		yday := d.YearDay()
+		if !contains(r.ByYearDay, yday) && !contains(r.ByYearDay, yday-days-1) {
+			return false
+		}
+	}
+	if len(byMonthDay) > 0 {
+		days := daysInMonth(year, month)
+		if !contains(byMonthDay, day) && !contains(byMonthDay, day-days-1) {
+			return false
+		}
+	}
+	if len(byDay) > 0 && !it.matchWeekday(d, byDay) {
+		return false
+	}
+	return true
+}
+
+// matchWeekday возвращает true если день подходит BYDAY
+// Номер дня недели учитывается в пределах месяца для MONTHLY и YEARLY с BYMONTH
+// и в пределах года для YEARLY
+func (it *ruleIterator) matchWeekday(d time.Time, byDay []Weekday) bool {
+	r := it.rule
+	year, month, _ := d.Date()
+	for _, w := range byDay {
+		if w.Day != d.Weekday() {
+			continue
+		}
+		if w.N == 0 || r.Freq > Monthly || len(r.ByWeekNo) > 0 {
+			return true
+		}
+		var first, last time.Time
+		if r.Freq == Monthly || len(r.ByMonth) > 0 {
+			first = date(year, month, 1)
+			last = first.AddDate(0, 1, -1)
+		} else {
+			first = date(year, time.January, 1)
+			last = date(year, time.December, 31)
+		}
+		if w.N > 0 && int(d.Sub(first)/(24*time.Hour))/7+1 == w.N {
+			return true
+		}
+		if w.N < 0 && int(last.Sub(d)/(24*time.Hour))/7+1 == -w.N {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWeekNo возвращает true если неделя дня подходит BYWEEKNO
+// Первая неделя года содержит не менее 4 дней этого года
+func matchWeekNo(d time.Time, weekStart time.Weekday, byWeekNo []int) bool {
+	year := d.Year()
+	start := firstWeekStart(year, weekStart)
+	if d.Before(start) {
+		year--
+		start = firstWeekStart(year, weekStart)
+	} else if next := firstWeekStart(year+1, weekStart); !d.Before(next) {
+		year++
+		start = next
+	}
+	weeks := int(firstWeekStart(year+1, weekStart).Sub(start) / (7 * 24 * time.Hour))
+	week := int(d.Sub(start)/(7*24*time.Hour)) + 1
+	return contains(byWeekNo, week) || contains(byWeekNo, week-weeks-1)
+}
+
+// firstWeekStart возвращает начало первой недели года
+func firstWeekStart(year int, weekStart time.Weekday) time.Time {
+	jan1 := date(year, time.January, 1)
+	offset := (int(jan1.Weekday()) - int(weekStart) + 7) % 7
+	if offset <= 3 {
+		return jan1.AddDate(0, 0, -offset)
+	}
+	return jan1.AddDate(0, 0, 7-offset)
+}
+
+// setPos применяет BYSETPOS к отсортированным повторениям периода
+func setPos(values []time.Time, positions []int) []time.Time {
+	if len(positions) == 0 || len(values) == 0 {
+		return values
+	}
+	var result []time.Time
+	seen := map[int]bool{}
+	for _, position := range positions {
+		i := position - 1
+		if position < 0 {
+			i = len(values) + position
+		}
+		if i < 0 || i >= len(values) || seen[i] {
+			continue
+		}
+		seen[i] = true
+		result = append(result, values[i])
+	}
+	sortTimes(result)
+	return result
+}
+
+// wallTime возвращает момент с заданным временем на часах в location
+//
+// Для неоднозначного времени при переходе на зимнее время выбирается более раннее,
+// для несуществующего времени при переходе на летнее время используется смещение
+// до перехода (RFC 5545, 3.3.5), то есть время сдвигается вперёд на размер перехода
+func wallTime(year int, month time.Month, day, hour, minute, second, nanosecond int, location *time.Location) time.Time {
+	naive := time.Date(year, month, day, hour, minute, second, nanosecond, time.UTC)
+	_, before := naive.Add(-12 * time.Hour).In(location).Zone()
+	_, after := naive.Add(12 * time.Hour).In(location).Zone()
+	var result time.Time
+	for _, offset := range []int{before, after} {
+		t := naive.Add(-time.Duration(offset) * time.Second).In(location)
+		if !sameClock(t, naive) {
+			continue
+		}
+		if result.IsZero() || t.Before(result) {
+			result = t
+		}
+	}
+	if result.IsZero() {
+		result = naive.Add(-time.Duration(before) * time.Second).In(location)
+	}
+	return result
+}
+
+// sameClock возвращает true если показания часов t совпадают с naive
+func sameClock(t, naive time.Time) bool {
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := naive.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2 &&
+		t.Hour() == naive.Hour() &&
+		t.Minute() == naive.Minute() &&
+		t.Second() == naive.Second()
+}
+
+// date возвращает полночь даты в UTC
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// daysInYear возвращает количество дней в году
+func daysInYear(year int) int {
+	return date(year, time.December, 31).YearDay()
+}
+
+// daysInMonth возвращает количество дней в месяце
+func daysInMonth(year int, month time.Month) int {
+	return date(year, month+1, 0).Day()
+}
+
+// contains возвращает true если values содержит value
+func contains(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sorted возвращает отсортированную копию values
+func sorted(values []int) []int {
+	result := append([]int(nil), values...)
+	sort.Ints(result)
+	return result
+}
+
+// sortTimes сортирует моменты по возрастанию
+func sortTimes(values []time.Time) {
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Before(values[j])
+	})
+}
@@ -0,0 +1,285 @@
+// Package rrule реализует правила повторения RFC 5545 (RRULE, EXRULE, RDATE, EXDATE)
+// и возвращает повторения как times.Time
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency это частота повторения FREQ
+type Frequency int
+
+const (
+	Yearly Frequency = iota
+	Monthly
+	Weekly
+	Daily
+	Hourly
+	Minutely
+	Secondly
+)
+
+var frequencyNames = []string{
+	"YEARLY",
+	"MONTHLY",
+	"WEEKLY",
+	"DAILY",
+	"HOURLY",
+	"MINUTELY",
+	"SECONDLY",
+}
+
+// String возвращает название частоты RFC 5545
+func (f Frequency) String() string {
+	if f < Yearly || f > Secondly {
+		return fmt.Sprintf("Frequency(%d)", int(f))
+	}
+	return frequencyNames[f]
+}
+
+// parseFrequency возвращает частоту по названию RFC 5545
+func parseFrequency(s string) (Frequency, error) {
+	for i, name := range frequencyNames {
+		if name == s {
+			return Frequency(i), nil
+		}
+	}
+	return 0, fmt.Errorf("rrule: unknown FREQ %q", s)
+}
+
+var weekdayNames = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Weekday это день недели BYDAY с необязательным номером
+// N = 0 - каждый такой день, N > 0 - N-й с начала месяца или года, N < 0 - с конца
+type Weekday struct {
+	Day time.Weekday
+	N   int
+}
+
+// String возвращает день недели в формате RFC 5545, например MO, 1FR, -1SU
+func (w Weekday) String() string {
+	if w.N == 0 {
+		return weekdayNames[w.Day]
+	}
+	return strconv.Itoa(w.N) + weekdayNames[w.Day]
+}
+
+// parseWeekday разбирает день недели в формате RFC 5545
+func parseWeekday(s string) (Weekday, error) {
+	if len(s) < 2 {
+		return Weekday{}, fmt.Errorf("rrule: invalid weekday %q", s)
+	}
+	name := s[len(s)-2:]
+	for i, weekday := range weekdayNames {
+		if weekday != name {
+			continue
+		}
+		w := Weekday{Day: time.Weekday(i)}
+		if number := s[:len(s)-2]; number != "" {
+			n, err := strconv.Atoi(number)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return Weekday{}, fmt.Errorf("rrule: invalid weekday %q", s)
+			}
+			w.N = n
+		}
+		return w, nil
+	}
+	return Weekday{}, fmt.Errorf("rrule: invalid weekday %q", s)
+}
+
+// Rule это правило повторения RFC 5545
+//
+// Пример:
+//   FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20180301T000000Z
+//
+// Dtstart задаёт первое повторение, время суток по умолчанию
+// и часовой пояс, в котором вычисляются повторения
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	Count    int
+
+	// Until это последний допустимый момент, нулевое значение - без ограничения
+	Until time.Time
+
+	// WeekStart это начало недели WKST, по умолчанию понедельник
+	WeekStart time.Weekday
+
+	BySecond   []int
+	ByMinute   []int
+	ByHour     []int
+	ByDay      []Weekday
+	ByMonthDay []int
+	ByYearDay  []int
+	ByWeekNo   []int
+	ByMonth    []int
+	BySetPos   []int
+
+	Dtstart time.Time
+}
+
+// ParseRule разбирает правило RFC 5545, префикс RRULE: допускается
+// Dtstart не заполняется
+// UNTIL без часового пояса и в виде даты считается временем в location,
+// дата означает конец этого дня
+func ParseRule(s string, location *time.Location) (*Rule, error) {
+	if location == nil {
+		return nil, errors.New("rrule: empty time location")
+	}
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "RRULE:"), "EXRULE:")
+	r := &Rule{
+		Interval:  1,
+		WeekStart: time.Monday,
+	}
+	freq := false
+	for _, part := range strings.Split(s, ";") {
+		i := strings.IndexByte(part, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("rrule: invalid rule part %q", part)
+		}
+		name, value := part[:i], part[i+1:]
+		var err error
+		switch name {
+		case "FREQ":
+			r.Freq, err = parseFrequency(value)
+			freq = true
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+			if err == nil && r.Interval < 1 {
+				err = fmt.Errorf("rrule: invalid INTERVAL %q", value)
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+			if err == nil && r.Count < 1 {
+				err = fmt.Errorf("rrule: invalid COUNT %q", value)
+			}
+		case "UNTIL":
+			r.Until, err = parseUntil(value, location)
+		case "WKST":
+			var w Weekday
+			w, err = parseWeekday(value)
+			if err == nil && w.N != 0 {
+				err = fmt.Errorf("rrule: invalid WKST %q", value)
+			}
+			r.WeekStart = w.Day
+		case "BYSECOND":
+			r.BySecond, err = parseInts(value, 0, 60, false)
+		case "BYMINUTE":
+			r.ByMinute, err = parseInts(value, 0, 59, false)
+		case "BYHOUR":
+			r.ByHour, err = parseInts(value, 0, 23, false)
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				var w Weekday
+				w, err = parseWeekday(day)
+				if err != nil {
+					break
+				}
+				r.ByDay = append(r.ByDay, w)
+			}
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseInts(value, 1, 31, true)
+		case "BYYEARDAY":
+			r.ByYearDay, err = parseInts(value, 1, 366, true)
+		case "BYWEEKNO":
+			r.ByWeekNo, err = parseInts(value, 1, 53, true)
+		case "BYMONTH":
+			r.ByMonth, err = parseInts(value, 1, 12, false)
+		case "BYSETPOS":
+			r.BySetPos, err = parseInts(value, 1, 366, true)
+		default:
+			err = fmt.Errorf("rrule: unknown rule part %q", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !freq {
+		return nil, errors.New("rrule: FREQ is required")
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return nil, errors.New("rrule: COUNT and UNTIL must not occur together")
+	}
+	return r, nil
+}
+
+// parseInts разбирает список чисел в диапазоне [min, max] или [-max, -min] если signed
+func parseInts(s string, min, max int, signed bool) ([]int, error) {
+	var result []int
+	for _, part := range strings.Split(s, ",") {
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("rrule: invalid number %q", part)
+		}
+		abs := value
+		if signed && abs < 0 {
+			abs = -abs
+		}
+		if abs < min || abs > max {
+			return nil, fmt.Errorf("rrule: number %d out of range", value)
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+// parseUntil разбирает UNTIL
+func parseUntil(s string, location *time.Location) (time.Time, error) {
+	if len(s) == 8 {
+		t, err := parseValue(s, location)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return parseValue(s, location)
+}
+
+// String возвращает правило в формате RFC 5545 без префикса RRULE:
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(utcLayout))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+	parts = appendInts(parts, "BYSECOND", r.BySecond)
+	parts = appendInts(parts, "BYMINUTE", r.ByMinute)
+	parts = appendInts(parts, "BYHOUR", r.ByHour)
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			days = append(days, day.String())
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	parts = appendInts(parts, "BYMONTHDAY", r.ByMonthDay)
+	parts = appendInts(parts, "BYYEARDAY", r.ByYearDay)
+	parts = appendInts(parts, "BYWEEKNO", r.ByWeekNo)
+	parts = appendInts(parts, "BYMONTH", r.ByMonth)
+	parts = appendInts(parts, "BYSETPOS", r.BySetPos)
+	return strings.Join(parts, ";")
+}
+
+// appendInts добавляет часть правила со списком чисел
+func appendInts(parts []string, name string, values []int) []string {
+	if len(values) == 0 {
+		return parts
+	}
+	s := make([]string, 0, len(values))
+	for _, value := range values {
+		s = append(s, strconv.Itoa(value))
+	}
+	return append(parts, name+"="+strings.Join(s, ","))
+}
@@ -0,0 +1,238 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mantyr/times"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRule(t *testing.T) {
+	Convey("Проверяем разбор правила", t, func() {
+		r, err := ParseRule("RRULE:FREQ=WEEKLY;INTERVAL=2;UNTIL=20180301T000000Z;WKST=SU;BYDAY=MO,-1FR", times.MoscowLocation)
+		So(err, ShouldBeNil)
+		So(r.Freq, ShouldEqual, Weekly)
+		So(r.Interval, ShouldEqual, 2)
+		So(r.WeekStart, ShouldEqual, time.Sunday)
+		So(r.ByDay, ShouldResemble, []Weekday{{Day: time.Monday}, {Day: time.Friday, N: -1}})
+		So(r.Until.Equal(time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)), ShouldBeTrue)
+		So(r.String(), ShouldEqual, "FREQ=WEEKLY;INTERVAL=2;UNTIL=20180301T000000Z;WKST=SU;BYDAY=MO,-1FR")
+
+		r, err = ParseRule("FREQ=DAILY;UNTIL=20180101", times.MoscowLocation)
+		So(err, ShouldBeNil)
+		So(r.Until.String(), ShouldEqual, "2018-01-01 23:59:59.999999999 +0300 MSK")
+
+		Convey("Некорректные значения", func() {
+			for _, s := range []string{
+				"",
+				"INTERVAL=2",
+				"FREQ=HOURLY;INTERVAL=0",
+				"FREQ=SOMETIMES",
+				"FREQ=DAILY;COUNT=2;UNTIL=20180101T000000Z",
+				"FREQ=DAILY;BYDAY=XX",
+				"FREQ=DAILY;BYMONTH=13",
+				"FREQ=DAILY;BYMONTHDAY=0",
+				"FREQ=DAILY;WKST=1MO",
+				"FREQ=DAILY;COLOR=RED",
+			} {
+				_, err := ParseRule(s, times.MoscowLocation)
+				So(err, ShouldNotBeNil)
+			}
+			_, err := ParseRule("FREQ=DAILY", nil)
+			So(err, ShouldNotBeNil)
+		})
+	})
+	Convey("Проверяем повторения", t, func() {
+		testRule(
+			"FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20180110T090000Z",
+			"2018-01-01T09:00:00+03:00",
+			0,
+			"2018-01-01T09:00:00+03:00",
+			"2018-01-03T09:00:00+03:00",
+			"2018-01-08T09:00:00+03:00",
+			"2018-01-10T09:00:00+03:00",
+		)
+		testRule(
+			"FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			"2018-01-01T10:00:00+03:00",
+			0,
+			"2018-01-26T10:00:00+03:00",
+			"2018-02-23T10:00:00+03:00",
+			"2018-03-30T10:00:00+03:00",
+		)
+		testRule(
+			"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;COUNT=3",
+			"2018-01-01T00:00:00+03:00",
+			0,
+			"2018-01-31T00:00:00+03:00",
+			"2018-02-28T00:00:00+03:00",
+			"2018-03-30T00:00:00+03:00",
+		)
+		testRule(
+			"FREQ=MONTHLY;BYMONTHDAY=31",
+			"2018-01-31T00:00:00+03:00",
+			4,
+			"2018-01-31T00:00:00+03:00",
+			"2018-03-31T00:00:00+03:00",
+			"2018-05-31T00:00:00+03:00",
+			"2018-07-31T00:00:00+03:00",
+		)
+		testRule(
+			"FREQ=MONTHLY;BYMONTHDAY=-1",
+			"2018-01-31T00:00:00+03:00",
+			3,
+			"2018-01-31T00:00:00+03:00",
+			"2018-02-28T00:00:00+03:00",
+			"2018-03-31T00:00:00+03:00",
+		)
+		testRule(
+			"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29",
+			"2016-02-29T00:00:00+03:00",
+			2,
+			"2016-02-29T00:00:00+03:00",
+			"2020-02-29T00:00:00+03:00",
+		)
+		testRule(
+			"FREQ=YEARLY;BYWEEKNO=1;BYDAY=MO",
+			"2018-01-01T00:00:00+03:00",
+			3,
+			"2018-01-01T00:00:00+03:00",
+			"2018-12-31T00:00:00+03:00",
+			"2019-12-30T00:00:00+03:00",
+		)
+		testRule(
+			"FREQ=DAILY;INTERVAL=10;COUNT=3",
+			"2018-01-25T00:00:00+03:00",
+			0,
+			"2018-01-25T00:00:00+03:00",
+			"2018-02-04T00:00:00+03:00",
+			"2018-02-14T00:00:00+03:00",
+		)
+		testRule(
+			"FREQ=HOURLY;INTERVAL=5;BYHOUR=9,10,11,12,13,14,15,16,17",
+			"2018-01-01T09:00:00+03:00",
+			4,
+			"2018-01-01T09:00:00+03:00",
+			"2018-01-01T14:00:00+03:00",
+			"2018-01-02T10:00:00+03:00",
+			"2018-01-02T15:00:00+03:00",
+		)
+		testRule(
+			"FREQ=MINUTELY;INTERVAL=20;BYHOUR=9",
+			"2018-01-01T08:00:00+03:00",
+			4,
+			"2018-01-01T09:00:00+03:00",
+			"2018-01-01T09:20:00+03:00",
+			"2018-01-01T09:40:00+03:00",
+			"2018-01-02T09:00:00+03:00",
+		)
+	})
+	Convey("Проверяем переход на летнее и зимнее время", t, func() {
+		berlin, err := time.LoadLocation("Europe/Berlin")
+		So(err, ShouldBeNil)
+		Convey("Несуществующее время сдвигается вперёд", func() {
+			r, err := ParseRule("FREQ=DAILY;COUNT=3", berlin)
+			So(err, ShouldBeNil)
+			r.Dtstart = time.Date(2018, 3, 24, 2, 30, 0, 0, berlin)
+			So(testStrings(r.All(0)), ShouldResemble, []string{
+				"2018-03-24T02:30:00+01:00",
+				"2018-03-25T03:30:00+02:00",
+				"2018-03-26T02:30:00+02:00",
+			})
+		})
+		Convey("Неоднозначное время выбирается раньшее", func() {
+			r, err := ParseRule("FREQ=DAILY;COUNT=3", berlin)
+			So(err, ShouldBeNil)
+			r.Dtstart = time.Date(2018, 10, 27, 2, 30, 0, 0, berlin)
+			So(testStrings(r.All(0)), ShouldResemble, []string{
+				"2018-10-27T02:30:00+02:00",
+				"2018-10-28T02:30:00+02:00",
+				"2018-10-29T02:30:00+01:00",
+			})
+		})
+		Convey("Почасовые повторения идут по точному времени", func() {
+			r, err := ParseRule("FREQ=HOURLY;COUNT=3", berlin)
+			So(err, ShouldBeNil)
+			r.Dtstart = time.Date(2018, 10, 28, 1, 30, 0, 0, berlin)
+			So(testStrings(r.All(0)), ShouldResemble, []string{
+				"2018-10-28T01:30:00+02:00",
+				"2018-10-28T02:30:00+02:00",
+				"2018-10-28T02:30:00+01:00",
+			})
+		})
+	})
+	Convey("Проверяем запросы", t, func() {
+		r, err := ParseRule("FREQ=DAILY", times.MoscowLocation)
+		So(err, ShouldBeNil)
+		r.Dtstart = time.Date(2018, 1, 1, 9, 0, 0, 0, times.MoscowLocation)
+		start := times.Time(time.Date(2018, 1, 3, 9, 0, 0, 0, times.MoscowLocation))
+		end := times.Time(time.Date(2018, 1, 5, 9, 0, 0, 0, times.MoscowLocation))
+
+		So(testStrings(r.Between(start, end, true)), ShouldResemble, []string{
+			"2018-01-03T09:00:00+03:00",
+			"2018-01-04T09:00:00+03:00",
+			"2018-01-05T09:00:00+03:00",
+		})
+		So(testStrings(r.Between(start, end, false)), ShouldResemble, []string{
+			"2018-01-04T09:00:00+03:00",
+		})
+
+		next, ok, err := r.After(start, false)
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+		So(next.String(), ShouldEqual, "2018-01-04T09:00:00+03:00")
+
+		previous, ok, err := r.Before(start, false)
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+		So(previous.String(), ShouldEqual, "2018-01-02T09:00:00+03:00")
+
+		_, ok, err = r.Before(times.Time(r.Dtstart), false)
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeFalse)
+	})
+	Convey("Проверяем бесконечные правила и пустой DTSTART", t, func() {
+		r, err := ParseRule("FREQ=SECONDLY", times.MoscowLocation)
+		So(err, ShouldBeNil)
+		So(r.IsBounded(), ShouldBeFalse)
+		_, err = r.Iterator()
+		So(err, ShouldNotBeNil)
+		_, err = r.All(3)
+		So(err, ShouldNotBeNil)
+		_, _, err = r.After(times.Time(time.Date(2018, 1, 1, 0, 0, 0, 0, times.MoscowLocation)), false)
+		So(err, ShouldNotBeNil)
+
+		r.Dtstart = time.Date(2018, 1, 1, 0, 0, 0, 0, times.MoscowLocation)
+		_, err = r.All(0)
+		So(err, ShouldNotBeNil)
+		So(testStrings(r.All(2)), ShouldResemble, []string{
+			"2018-01-01T00:00:00+03:00",
+			"2018-01-01T00:00:01+03:00",
+		})
+
+		r.Count = 2
+		So(r.IsBounded(), ShouldBeTrue)
+		So(testStrings(r.All(0)), ShouldHaveLength, 2)
+	})
+}
+
+func testRule(rule, dtstart string, n int, expected ...string) {
+	Convey(rule, func() {
+		r, err := ParseRule(rule, times.MoscowLocation)
+		So(err, ShouldBeNil)
+		start, err := time.Parse(time.RFC3339, dtstart)
+		So(err, ShouldBeNil)
+		r.Dtstart = start.In(times.MoscowLocation)
+		So(testStrings(r.All(n)), ShouldResemble, append([]string{}, expected...))
+	})
+}
+
+func testStrings(values []times.Time, err error) []string {
+	So(err, ShouldBeNil)
+	result := []string{}
+	for _, t := range values {
+		result = append(result, t.Time().Format(time.RFC3339))
+	}
+	return result
+}
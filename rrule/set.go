@@ -0,0 +1,469 @@
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mantyr/times"
+)
+
+const (
+	// utcLayout это формат DATE-TIME в UTC
+	utcLayout = "20060102T150405Z"
+
+	// localLayout это формат DATE-TIME без часового пояса
+	localLayout = "20060102T150405"
+
+	// dateLayout это формат DATE
+	dateLayout = "20060102"
+)
+
+// Set это набор повторений RFC 5545: DTSTART, RRULE, EXRULE, RDATE и EXDATE
+//
+// Пример:
+//   DTSTART;TZID=Europe/Moscow:20180101T090000
+//   RRULE:FREQ=WEEKLY;BYDAY=MO,WE
+//   EXDATE;TZID=Europe/Moscow:20180103T090000
+//
+// Dtstart всегда является первым повторением,
+// повторения из EXRULE и EXDATE исключаются
+type Set struct {
+	Dtstart time.Time
+	RRules  []*Rule
+	ExRules []*Rule
+	RDates  []time.Time
+	ExDates []time.Time
+}
+
+// ParseSet разбирает набор повторений из строк RFC 5545
+// Значения без часового пояса считаются временем в location
+func ParseSet(text string, location *time.Location) (*Set, error) {
+	if location == nil {
+		return nil, errors.New("rrule: empty time location")
+	}
+	s := &Set{}
+	var rrules, exrules []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		i := strings.IndexByte(line, ':')
+		if i < 0 {
+			rrules = append(rrules, line)
+			continue
+		}
+		name, value := line[:i], line[i+1:]
+		params := strings.Split(name, ";")
+		switch params[0] {
+		case "DTSTART":
+			values, err := parseValues(value, params[1:], location)
+			if err != nil {
+				return nil, err
+			}
+			if len(values) != 1 {
+				return nil, fmt.Errorf("rrule: invalid DTSTART %q", value)
+			}
+			s.Dtstart = values[0]
+		case "RRULE":
+			rrules = append(rrules, value)
+		case "EXRULE":
+			exrules = append(exrules, value)
+		case "RDATE", "EXDATE":
+			values, err := parseValues(value, params[1:], location)
+			if err != nil {
+				return nil, err
+			}
+			if params[0] == "RDATE" {
+				s.RDates = append(s.RDates, values...)
+			} else {
+				s.ExDates = append(s.ExDates, values...)
+			}
+		default:
+			return nil, fmt.Errorf("rrule: unknown property %q", params[0])
+		}
+	}
+	if s.Dtstart.IsZero() {
+		return nil, errors.New("rrule: DTSTART is required")
+	}
+	for _, value := range rrules {
+		r, err := ParseRule(value, s.Dtstart.Location())
+		if err != nil {
+			return nil, err
+		}
+		r.Dtstart = s.Dtstart
+		s.RRules = append(s.RRules, r)
+	}
+	for _, value := range exrules {
+		r, err := ParseRule(value, s.Dtstart.Location())
+		if err != nil {
+			return nil, err
+		}
+		r.Dtstart = s.Dtstart
+		s.ExRules = append(s.ExRules, r)
+	}
+	return s, nil
+}
+
+// parseValues разбирает список значений с параметрами TZID и VALUE
+func parseValues(value string, params []string, location *time.Location) ([]time.Time, error) {
+	for _, param := range params {
+		switch {
+		case strings.HasPrefix(param, "TZID="):
+			var err error
+			location, err = times.LoadLocation(strings.Trim(param[len("TZID="):], `"`))
+			if err != nil {
+				return nil, err
+			}
+		case param == "VALUE=DATE", param == "VALUE=DATE-TIME":
+		default:
+			return nil, fmt.Errorf("rrule: unknown parameter %q", param)
+		}
+	}
+	var result []time.Time
+	for _, s := range strings.Split(value, ",") {
+		t, err := parseValue(s, location)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+// parseValue разбирает DATE или DATE-TIME
+// Значения без Z считаются временем в location
+func parseValue(s string, location *time.Location) (time.Time, error) {
+	var t time.Time
+	var err error
+	switch len(s) {
+	case len(utcLayout):
+		t, err = time.Parse(utcLayout, s)
+	case len(localLayout):
+		t, err = time.ParseInLocation(localLayout, s, location)
+	case len(dateLayout):
+		t, err = time.ParseInLocation(dateLayout, s, location)
+	default:
+		err = fmt.Errorf("rrule: invalid date %q", s)
+	}
+	return t, err
+}
+
+// String возвращает набор в формате RFC 5545, строки разделены \n
+func (s Set) String() string {
+	location := s.Dtstart.Location()
+	lines := []string{"DTSTART" + formatValue(s.Dtstart, location)}
+	for _, r := range s.RRules {
+		lines = append(lines, "RRULE:"+r.String())
+	}
+	for _, r := range s.ExRules {
+		lines = append(lines, "EXRULE:"+r.String())
+	}
+	for _, t := range s.RDates {
+		lines = append(lines, "RDATE"+formatValue(t, location))
+	}
+	for _, t := range s.ExDates {
+		lines = append(lines, "EXDATE"+formatValue(t, location))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatValue возвращает параметры и значение DATE-TIME в location
+func formatValue(t time.Time, location *time.Location) string {
+	if location == time.UTC {
+		return ":" + t.UTC().Format(utcLayout)
+	}
+	return ";TZID=" + location.String() + ":" + t.In(location).Format(localLayout)
+}
+
+// Iterator возвращает итератор повторений набора
+// Возвращает ошибку если Dtstart не задан
+func (s Set) Iterator() (*Iterator, error) {
+	if s.Dtstart.IsZero() {
+		return nil, errors.New("rrule: DTSTART is required")
+	}
+	it := &Iterator{
+		rdates:  sortedTimes(append([]time.Time{s.Dtstart}, s.RDates...)),
+		exdates: map[int64]bool{},
+	}
+	for _, r := range s.RRules {
+		it.rules = append(it.rules, newSource(*r, s.Dtstart))
+	}
+	for _, r := range s.ExRules {
+		it.exrules = append(it.exrules, newSource(*r, s.Dtstart))
+	}
+	for _, t := range s.ExDates {
+		it.exdates[t.UnixNano()] = true
+	}
+	return it, nil
+}
+
+// IsBounded возвращает true если количество повторений набора конечно
+func (s Set) IsBounded() bool {
+	for _, r := range s.RRules {
+		if !r.IsBounded() {
+			return false
+		}
+	}
+	return true
+}
+
+// All возвращает не более limit повторений
+// limit <= 0 означает все повторения и допускается только для конечного набора
+func (s Set) All(limit int) ([]times.Time, error) {
+	if limit <= 0 && !s.IsBounded() {
+		return nil, errors.New("rrule: limit is required for rule without COUNT or UNTIL")
+	}
+	it, err := s.Iterator()
+	if err != nil {
+		return nil, err
+	}
+	return it.take(limit), nil
+}
+
+// Between возвращает повторения между after и before
+func (s Set) Between(after, before times.Time, inclusive bool) ([]times.Time, error) {
+	it, err := s.Iterator()
+	if err != nil {
+		return nil, err
+	}
+	return it.between(after, before, inclusive), nil
+}
+
+// After возвращает первое повторение после t
+// Второе значение false если такого повторения нет
+func (s Set) After(t times.Time, inclusive bool) (times.Time, bool, error) {
+	it, err := s.Iterator()
+	if err != nil {
+		return times.Time{}, false, err
+	}
+	value, ok := it.after(t, inclusive)
+	return value, ok, nil
+}
+
+// Before возвращает последнее повторение до t
+// Второе значение false если такого повторения нет
+func (s Set) Before(t times.Time, inclusive bool) (times.Time, bool, error) {
+	it, err := s.Iterator()
+	if err != nil {
+		return times.Time{}, false, err
+	}
+	value, ok := it.before(t, inclusive)
+	return value, ok, nil
+}
+
+// Iterator возвращает итератор повторений правила
+// В отличие от Set, Dtstart не добавляется если не подходит правилу
+// Возвращает ошибку если Dtstart не задан
+func (r Rule) Iterator() (*Iterator, error) {
+	if r.Dtstart.IsZero() {
+		return nil, errors.New("rrule: DTSTART is required")
+	}
+	return &Iterator{
+		rules: []*source{newSource(r, r.Dtstart)},
+	}, nil
+}
+
+// IsBounded возвращает true если правило ограничено COUNT или UNTIL
+func (r Rule) IsBounded() bool {
+	return r.Count > 0 || !r.Until.IsZero()
+}
+
+// All возвращает не более limit повторений
+// limit <= 0 означает все повторения и допускается только для правила с COUNT или UNTIL
+func (r Rule) All(limit int) ([]times.Time, error) {
+	if limit <= 0 && !r.IsBounded() {
+		return nil, errors.New("rrule: limit is required for rule without COUNT or UNTIL")
+	}
+	it, err := r.Iterator()
+	if err != nil {
+		return nil, err
+	}
+	return it.take(limit), nil
+}
+
+// Between возвращает повторения между after и before
+func (r Rule) Between(after, before times.Time, inclusive bool) ([]times.Time, error) {
+	it, err := r.Iterator()
+	if err != nil {
+		return nil, err
+	}
+	return it.between(after, before, inclusive), nil
+}
+
+// After возвращает первое повторение после t
+// Второе значение false если такого повторения нет
+func (r Rule) After(t times.Time, inclusive bool) (times.Time, bool, error) {
+	it, err := r.Iterator()
+	if err != nil {
+		return times.Time{}, false, err
+	}
+	value, ok := it.after(t, inclusive)
+	return value, ok, nil
+}
+
+// Before возвращает последнее повторение до t
+// Второе значение false если такого повторения нет
+func (r Rule) Before(t times.Time, inclusive bool) (times.Time, bool, error) {
+	it, err := r.Iterator()
+	if err != nil {
+		return times.Time{}, false, err
+	}
+	value, ok := it.before(t, inclusive)
+	return value, ok, nil
+}
+
+// source это поток повторений правила с просмотром следующего значения
+type source struct {
+	iterator *ruleIterator
+	value    time.Time
+	ok       bool
+}
+
+// newSource возвращает поток повторений правила от dtstart
+func newSource(r Rule, dtstart time.Time) *source {
+	r.Dtstart = dtstart
+	s := &source{iterator: newRuleIterator(r)}
+	s.advance()
+	return s
+}
+
+// advance переходит к следующему повторению
+func (s *source) advance() {
+	s.value, s.ok = s.iterator.next()
+}
+
+// Iterator это итератор повторений в порядке возрастания без повторов
+type Iterator struct {
+	rules   []*source
+	exrules []*source
+	rdates  []time.Time
+	exdates map[int64]bool
+	last    time.Time
+	started bool
+}
+
+// Next возвращает следующее повторение
+// Второе значение false если повторения закончились
+func (it *Iterator) Next() (times.Time, bool) {
+	for {
+		t, ok := it.pop()
+		if !ok {
+			return times.Time{}, false
+		}
+		if it.started && !t.After(it.last) {
+			continue
+		}
+		it.last, it.started = t, true
+		if it.excluded(t) {
+			continue
+		}
+		return times.Time(t), true
+	}
+}
+
+// pop возвращает наименьшее значение среди правил и RDATE
+func (it *Iterator) pop() (time.Time, bool) {
+	var next *source
+	for _, s := range it.rules {
+		if s.ok && (next == nil || s.value.Before(next.value)) {
+			next = s
+		}
+	}
+	if len(it.rdates) > 0 && (next == nil || !next.value.Before(it.rdates[0])) {
+		t := it.rdates[0]
+		it.rdates = it.rdates[1:]
+		return t, true
+	}
+	if next == nil {
+		return time.Time{}, false
+	}
+	t := next.value
+	next.advance()
+	return t, true
+}
+
+// excluded возвращает true если t исключено EXDATE или EXRULE
+func (it *Iterator) excluded(t time.Time) bool {
+	if it.exdates[t.UnixNano()] {
+		return true
+	}
+	for _, s := range it.exrules {
+		for s.ok && s.value.Before(t) {
+			s.advance()
+		}
+		if s.ok && s.value.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// take возвращает не более limit повторений, limit <= 0 - все повторения
+func (it *Iterator) take(limit int) []times.Time {
+	var result []times.Time
+	for limit <= 0 || len(result) < limit {
+		t, ok := it.Next()
+		if !ok {
+			break
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+// between возвращает повторения между after и before
+func (it *Iterator) between(after, before times.Time, inclusive bool) []times.Time {
+	var result []times.Time
+	for {
+		t, ok := it.Next()
+		if !ok || !beforeLimit(t, before, inclusive) {
+			return result
+		}
+		if afterLimit(t, after, inclusive) {
+			result = append(result, t)
+		}
+	}
+}
+
+// after возвращает первое повторение после t
+func (it *Iterator) after(t times.Time, inclusive bool) (times.Time, bool) {
+	for {
+		value, ok := it.Next()
+		if !ok || afterLimit(value, t, inclusive) {
+			return value, ok
+		}
+	}
+}
+
+// before возвращает последнее повторение до t
+func (it *Iterator) before(t times.Time, inclusive bool) (times.Time, bool) {
+	var result times.Time
+	found := false
+	for {
+		value, ok := it.Next()
+		if !ok || !beforeLimit(value, t, inclusive) {
+			return result, found
+		}
+		result, found = value, true
+	}
+}
+
+// afterLimit возвращает true если t после limit
+func afterLimit(t, limit times.Time, inclusive bool) bool {
+	return t.Time().After(limit.Time()) || inclusive && t.Time().Equal(limit.Time())
+}
+
+// beforeLimit возвращает true если t до limit
+func beforeLimit(t, limit times.Time, inclusive bool) bool {
+	return t.Time().Before(limit.Time()) || inclusive && t.Time().Equal(limit.Time())
+}
+
+// sortedTimes возвращает отсортированную копию values
+func sortedTimes(values []time.Time) []time.Time {
+	result := append([]time.Time(nil), values...)
+	sortTimes(result)
+	return result
+}
@@ -0,0 +1,90 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mantyr/times"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSet(t *testing.T) {
+	Convey("Проверяем набор повторений", t, func() {
+		source := "DTSTART;TZID=Europe/Moscow:20180101T090000\n" +
+			"RRULE:FREQ=WEEKLY;COUNT=4;BYDAY=MO,WE\n" +
+			"RDATE;TZID=Europe/Moscow:20180105T120000\n" +
+			"EXDATE;TZID=Europe/Moscow:20180103T090000"
+		set, err := ParseSet(source, time.UTC)
+		So(err, ShouldBeNil)
+		So(set.String(), ShouldEqual, source)
+		So(testStrings(set.All(0)), ShouldResemble, []string{
+			"2018-01-01T09:00:00+03:00",
+			"2018-01-05T12:00:00+03:00",
+			"2018-01-08T09:00:00+03:00",
+			"2018-01-10T09:00:00+03:00",
+		})
+
+		next, ok, err := set.After(times.Time(time.Date(2018, 1, 2, 0, 0, 0, 0, times.MoscowLocation)), false)
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+		So(next.String(), ShouldEqual, "2018-01-05T12:00:00+03:00")
+	})
+	Convey("Проверяем DTSTART вне правила и EXRULE", t, func() {
+		set, err := ParseSet(
+			"DTSTART:20180102T060000Z\r\n"+
+				"RRULE:FREQ=WEEKLY;BYDAY=MO;COUNT=3\r\n"+
+				"EXRULE:FREQ=MONTHLY;BYDAY=2MO",
+			time.UTC,
+		)
+		So(err, ShouldBeNil)
+		So(testStrings(set.All(0)), ShouldResemble, []string{
+			"2018-01-02T06:00:00Z",
+			"2018-01-15T06:00:00Z",
+			"2018-01-22T06:00:00Z",
+		})
+	})
+	Convey("Проверяем значения без часового пояса и даты", t, func() {
+		set, err := ParseSet(
+			"DTSTART;VALUE=DATE:20180101\n"+
+				"RRULE:FREQ=DAILY;UNTIL=20180103\n"+
+				"RDATE;VALUE=DATE:20180101,20180110",
+			times.MoscowLocation,
+		)
+		So(err, ShouldBeNil)
+		So(testStrings(set.All(0)), ShouldResemble, []string{
+			"2018-01-01T00:00:00+03:00",
+			"2018-01-02T00:00:00+03:00",
+			"2018-01-03T00:00:00+03:00",
+			"2018-01-10T00:00:00+03:00",
+		})
+		So(testStrings(set.All(2)), ShouldHaveLength, 2)
+	})
+	Convey("Проверяем бесконечный набор", t, func() {
+		set, err := ParseSet("DTSTART:20180101T000000Z\nRRULE:FREQ=MINUTELY", time.UTC)
+		So(err, ShouldBeNil)
+		So(set.IsBounded(), ShouldBeFalse)
+		_, err = set.All(0)
+		So(err, ShouldNotBeNil)
+		So(testStrings(set.All(2)), ShouldResemble, []string{
+			"2018-01-01T00:00:00Z",
+			"2018-01-01T00:01:00Z",
+		})
+
+		_, err = (Set{}).Iterator()
+		So(err, ShouldNotBeNil)
+	})
+	Convey("Некорректные значения", t, func() {
+		for _, s := range []string{
+			"RRULE:FREQ=DAILY",
+			"DTSTART:2018",
+			"DTSTART;TZID=Mars/Olympus:20180101T000000",
+			"DTSTART:20180101T000000Z\nRRULE:FREQ=NEVER",
+			"DTSTART:20180101T000000Z\nDTEND:20180101T000000Z",
+		} {
+			_, err := ParseSet(s, time.UTC)
+			So(err, ShouldNotBeNil)
+		}
+		_, err := ParseSet("DTSTART:20180101T000000Z", nil)
+		So(err, ShouldNotBeNil)
+	})
+}
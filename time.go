@@ -7,7 +7,6 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"strings"
 	"time"
 )
 
@@ -48,21 +47,22 @@ type Time time.Time
 // на основе стандартной метки времени в UTC
 func NewTime(t time.Time, location *time.Location) (*Time, error) {
 	if location == nil {
-		return nil, errors.New("empty time location")
+		return nil, errors.New("times: empty time location")
 	}
 	newTime := Time(t.In(location))
 	return &newTime, nil
 }
 
-// NewCurrentTime возвращает текущее время в UTC
+// NewCurrentTime возвращает текущее время в UTC по часам по умолчанию, см. SetDefaultClock
 func NewCurrentTime() (*Time, error) {
-	return NewTime(time.Now(), time.UTC)
+	return NewTime(DefaultClock().Now(), time.UTC)
 }
 
-// NewTimeString возвращает время на основе строки в location Europe/Moscow
-func NewTimeString(date string, location *time.Location) (*Time, error) {
+// NewTimeString возвращает время на основе строки в location
+// Необязательный parser заменяет DefaultParser
+func NewTimeString(date string, location *time.Location, parser ...*Parser) (*Time, error) {
 	t := &Time{}
-	err := t.setTimeString(date, location)
+	err := t.setTimeString(date, location, getParser(parser))
 	if err != nil {
 		return nil, err
 	}
@@ -80,14 +80,34 @@ func (t *Time) Scan(src interface{}) error {
 }
 
 // CustomScan это реализация интерфейса database/sql.Scanner
-func (t *Time) CustomScan(src interface{}, location *time.Location) error {
+// Необязательный parser заменяет SQLParser
+// Поддерживаемые типы:
+//   time.Time
+//   string, []byte - разбираются парсером
+//   int64, float64 - числовые метки времени, см. Parser.SetNumeric
+func (t *Time) CustomScan(src interface{}, location *time.Location, parser ...*Parser) error {
+	p := getParserOr(parser, SQLParser)
 	switch v := src.(type) {
 	case time.Time:
 		return t.setTime(v, location)
 	case string:
-		return t.setTimeString(v, location)
+		return t.setTimeString(v, location, p)
+	case []byte:
+		return t.setTimeString(string(v), location, p)
+	case int64:
+		date, err := p.ParseInt(v, location)
+		if err != nil {
+			return err
+		}
+		return t.setTime(date, location)
+	case float64:
+		date, err := p.ParseFloat(v, location)
+		if err != nil {
+			return err
+		}
+		return t.setTime(date, location)
 	}
-	return fmt.Errorf("expected value type time.Time or string but actual %T", src)
+	return fmt.Errorf("times: expected value type time.Time, string, []byte, int64 or float64 but actual %T", src)
 }
 
 // Value это реализация database/sql/driver.Valuer
@@ -112,17 +132,22 @@ func (t *Time) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 }
 
 // CustomUnmarshalXML необходим для декодирования даты и времени
+// Необязательный parser заменяет DefaultParser
 func (t *Time) CustomUnmarshalXML(
 	d *xml.Decoder,
 	start xml.StartElement,
 	location *time.Location,
+	parser ...*Parser,
 ) error {
 	var data string
 	err := d.DecodeElement(&data, &start)
 	if err != nil {
 		return err
 	}
-	return t.setTimeString(data, location)
+	return withField(
+		t.setTimeString(data, location, getParser(parser)),
+		start.Name.Local,
+	)
 }
 
 // UnmarshalXMLAttr необходим для декодирования даты и времени
@@ -131,8 +156,12 @@ func (t *Time) UnmarshalXMLAttr(attr xml.Attr) error {
 }
 
 // CustomUnmarshalXMLAttr необходим для декодирования даты и времени
-func (t *Time) CustomUnmarshalXMLAttr(attr xml.Attr, location *time.Location) error {
-	return t.setTimeString(attr.Value, location)
+// Необязательный parser заменяет DefaultParser
+func (t *Time) CustomUnmarshalXMLAttr(attr xml.Attr, location *time.Location, parser ...*Parser) error {
+	return withField(
+		t.setTimeString(attr.Value, location, getParser(parser)),
+		attr.Name.Local,
+	)
 }
 
 // UnmarshalJSON необходим для декодирования даты и времени
@@ -141,46 +170,42 @@ func (t *Time) UnmarshalJSON(data []byte) error {
 }
 
 // CustomUnmarshalJSON необходим для декодирования даты и времени
-func (t *Time) CustomUnmarshalJSON(data []byte, location *time.Location) error {
+// Необязательный parser заменяет DefaultParser
+func (t *Time) CustomUnmarshalJSON(data []byte, location *time.Location, parser ...*Parser) error {
 	if location == nil {
-		return errors.New("empty time location")
+		return errors.New("times: empty time location")
 	}
 	var date string
 	err := json.Unmarshal(data, &date)
 	if err != nil {
 		return err
 	}
-	return t.setTimeString(date, location)
+	return t.setTimeString(date, location, getParser(parser))
 }
 
 // setLocalTime устанавливает локальное время
 func (t *Time) setTime(date time.Time, location *time.Location) error {
 	if location == nil {
-		return errors.New("empty time location")
+		return errors.New("times: empty time location")
 	}
 	*t = Time(date.In(location))
 	return nil
 }
 
 // setTimeString устанавливает время из строки
-func (t *Time) setTimeString(data string, location *time.Location) error {
+func (t *Time) setTimeString(data string, location *time.Location, parser *Parser) error {
 	if location == nil {
-		return errors.New("empty time location")
+		return errors.New("times: empty time location")
 	}
 	if data == "" {
 		*t = Time(time.Time{}.In(location))
 		return nil
 	}
-	localTime, err := time.ParseInLocation("2006-01-02T15:04:05", data, location)
-	if err != nil {
-		if strings.Contains(err.Error(), "extra text") {
-			localTime, err = time.Parse("2006-01-02T15:04:05Z07:00", data)
-		}
-	}
+	localTime, err := parser.Parse(data, location)
 	if err != nil {
 		return err
 	}
-	*t = Time(localTime.In(location))
+	*t = Time(localTime)
 	return nil
 }
 
@@ -192,30 +217,17 @@ func (t Time) Add(duration time.Duration) Time {
 // UntilEndMonthDays возвращает количество дней
 // от текущей даты до конца текущего месяца
 func (t Time) UntilEndMonthDays() int {
-	start := time.Time(t)
-	day := start.Day()
-	end := start.AddDate(0, 0, -(day - 1))
-	end = end.AddDate(0, +1, 0)
-	diff := end.Sub(start)
-
-	result := diff / (24 * time.Hour)
-	return int(result)
+	return t.Until(EndOfMonth, 0).Days()
 }
 
 // UntilEndNextMonthDays возвращает количество дней
 // от текущей даты до конца следующего месяца
 func (t Time) UntilEndNextMonthDays() int {
-	start := time.Time(t)
-	day := start.Day()
-	end := start.AddDate(0, 0, -(day - 1))
-	end = end.AddDate(0, +2, 0)
-	diff := end.Sub(start)
-
-	result := diff / (24 * time.Hour)
-	return int(result)
+	return t.Until(EndOfMonth, 1).Days()
 }
 
 // String возвращает текстовое представление
+// Формат: YYYY-MM-DDThh:mm:ss±hh:mm
 func (t Time) String() string {
 	return t.Format("2006-01-02T15:04:05Z07:00")
 }
@@ -226,17 +238,19 @@ func (t Time) MarshalXML(d *xml.Encoder, start xml.StartElement) error {
 }
 
 // CustomMarshalXML необходим для кодирования даты и времени
+// Необязательный precision заменяет доли секунды в format
 func (t Time) CustomMarshalXML(
 	d *xml.Encoder,
 	start xml.StartElement,
 	location *time.Location,
 	format string,
+	precision ...Precision,
 ) error {
 	if location == nil {
-		return errors.New("empty time location")
+		return errors.New("times: empty time location")
 	}
 	return d.EncodeElement(
-		t.Time().In(location).Format(format),
+		t.Time().In(location).Format(withPrecision(format, precision)),
 		start,
 	)
 }
@@ -247,20 +261,22 @@ func (t Time) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 }
 
 // CustomMarshalXMLAttr необходим для кодирования даты и времени
+// Необязательный precision заменяет доли секунды в format
 func (t Time) CustomMarshalXMLAttr(
 	name xml.Name,
 	location *time.Location,
 	format string,
+	precision ...Precision,
 ) (
 	xml.Attr,
 	error,
 ) {
 	if location == nil {
-		return xml.Attr{}, errors.New("empty time location")
+		return xml.Attr{}, errors.New("times: empty time location")
 	}
 	return xml.Attr{
 		Name:  name,
-		Value: t.Time().In(location).Format(format),
+		Value: t.Time().In(location).Format(withPrecision(format, precision)),
 	}, nil
 }
 
@@ -269,22 +285,56 @@ func (t Time) MarshalJSON() ([]byte, error) {
 	return t.CustomMarshalJSON(time.UTC, "2006-01-02T15:04:05Z07:00")
 }
 
-// MarshalJSON необходим для кодирования даты и времени
+// CustomMarshalJSON необходим для кодирования даты и времени
+// Необязательный precision заменяет доли секунды в format
 func (t Time) CustomMarshalJSON(
 	location *time.Location,
 	format string,
+	precision ...Precision,
 ) (
 	[]byte,
 	error,
 ) {
 	if location == nil {
-		return []byte{}, errors.New("empty time location")
+		return []byte{}, errors.New("times: empty time location")
 	}
 	return json.Marshal(
-		t.Time().In(location).Format(format),
+		t.Time().In(location).Format(withPrecision(format, precision)),
 	)
 }
 
+// MarshalText это реализация интерфейса encoding.TextMarshaler
+func (t Time) MarshalText() ([]byte, error) {
+	return t.CustomMarshalText(time.UTC, "2006-01-02T15:04:05Z07:00")
+}
+
+// CustomMarshalText это реализация интерфейса encoding.TextMarshaler
+// Необязательный precision заменяет доли секунды в format
+func (t Time) CustomMarshalText(
+	location *time.Location,
+	format string,
+	precision ...Precision,
+) (
+	[]byte,
+	error,
+) {
+	if location == nil {
+		return []byte{}, errors.New("times: empty time location")
+	}
+	return []byte(t.Time().In(location).Format(withPrecision(format, precision))), nil
+}
+
+// UnmarshalText это реализация интерфейса encoding.TextUnmarshaler
+func (t *Time) UnmarshalText(data []byte) error {
+	return t.CustomUnmarshalText(data, time.UTC)
+}
+
+// CustomUnmarshalText это реализация интерфейса encoding.TextUnmarshaler
+// Необязательный parser заменяет DefaultParser
+func (t *Time) CustomUnmarshalText(data []byte, location *time.Location, parser ...*Parser) error {
+	return t.setTimeString(string(data), location, getParser(parser))
+}
+
 // Equal сравнивает две даты
 func (t Time) Equal(y Time) bool {
 	return reflect.DeepEqual(t, y)
@@ -1,81 +1,61 @@
 package times
 
 import (
-	"encoding/xml"
 	"time"
 )
 
+// MoscowLocation это часовой пояс Europe/Moscow, никогда не равен nil, см. CheckLocations
 var MoscowLocation *time.Location
 
 func init() {
-	MoscowLocation, _ = time.LoadLocation("Europe/Moscow")
-}
-
-// MoscowTime это метка времени в Europe/Moscow
-// Особенности:
-//   Принимает любую локаль и преобразует в Europe/Moscow
-//   В XML возвращает UTC
-type MoscowTime struct {
-	Time
-}
-
-func NewMoscowTime(t time.Time) (*MoscowTime, error) {
-	date, err := NewTime(t, MoscowLocation)
+	var err error
+	MoscowLocation, err = LoadLocation("Europe/Moscow")
 	if err != nil {
-		return nil, err
+		locationsErr = err
+		MoscowLocation = time.FixedZone("MSK", 3*60*60)
 	}
-	return &MoscowTime{
-		Time: *date,
-	}, nil
 }
 
-func NewMoscowTimeString(s string) (*MoscowTime, error) {
-	t, err := NewTimeString(s, MoscowLocation)
-	if err != nil {
-		return nil, err
-	}
-	return &MoscowTime{
-		Time: *t,
-	}, nil
-}
-
-// MarshalXML необходим для кодирования даты и времени в UTC
-// Формат: YYYY-MM-DDThh:mm:ss.sssZ
-func (t MoscowTime) MarshalXML(d *xml.Encoder, start xml.StartElement) error {
-	return t.CustomMarshalXML(d, start, time.UTC, "2006-01-02T15:04:05Z07:00")
-}
-
-// MarshalXML необходим для кодирования даты и времени в UTC
-// Формат: YYYY-MM-DDThh:mm:ss.sssZ
-func (t MoscowTime) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
-	return t.CustomMarshalXMLAttr(name, time.UTC, "2006-01-02T15:04:05Z07:00")
-}
-
-// UnmarshalXML необходим для декодирования даты и времени
+// Moscow это часовой пояс Europe/Moscow для Zoned
 // Входной формат:
 //   YYYY-MM-DDThh:mm:ss.sssZ         - UTC
 //   YYYY-MM-DDThh:mm:ss.sss+/-hh:mm  - локальное время UTC со смещением
 //   YYYY-MM-DDThh:mm:ss.sss          - локальное время с часовым поясом Europe/Moscow по умолчанию
-func (t *MoscowTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
-	return t.CustomUnmarshalXML(d, start, MoscowLocation)
+// Выходной формат: YYYY-MM-DDThh:mm:ssZ в UTC
+type Moscow struct{}
+
+// Location возвращает Europe/Moscow
+func (Moscow) Location() *time.Location {
+	return MoscowLocation
 }
 
-// UnmarshalXMLAttr необходим для декодирования даты и времени
-func (t *MoscowTime) UnmarshalXMLAttr(attr xml.Attr) error {
-	return t.CustomUnmarshalXMLAttr(attr, MoscowLocation)
+// OutputLocation возвращает UTC
+func (Moscow) OutputLocation() *time.Location {
+	return time.UTC
 }
 
-// MarshalJSON необходим для кодирования даты и времени
-func (t MoscowTime) MarshalJSON() ([]byte, error) {
-	return t.CustomMarshalJSON(time.UTC, "2006-01-02T15:04:05Z07:00")
+// Layout возвращает формат кодирования
+func (Moscow) Layout() string {
+	return "2006-01-02T15:04:05Z07:00"
 }
 
-// UnmarshalJSON необходим для декодирования даты и времени
-func (t *MoscowTime) UnmarshalJSON(data []byte) error {
-	return t.CustomUnmarshalJSON(data, MoscowLocation)
+// MoscowTime это метка времени в Europe/Moscow
+// Особенности:
+//   Принимает любую локаль и преобразует в Europe/Moscow
+//   В XML и JSON возвращает UTC
+type MoscowTime = Zoned[Moscow]
+
+func NewMoscowTime(t time.Time) (*MoscowTime, error) {
+	return NewZoned[Moscow](t)
+}
+
+func NewMoscowTimeString(s string) (*MoscowTime, error) {
+	return NewZonedString[Moscow](s)
 }
 
-// Scan это реализация интерфейса database/sql.Scanner
-func (t *MoscowTime) Scan(src interface{}) error {
-	return t.CustomScan(src, MoscowLocation)
+// NullMoscowTime это метка времени MoscowTime, которая может отсутствовать
+type NullMoscowTime = NullZoned[Moscow]
+
+func NewNullMoscowTime(t time.Time) (*NullMoscowTime, error) {
+	return NewNullZoned[Moscow](t)
 }
@@ -0,0 +1,304 @@
+package times
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeOfDay это время суток без даты и часового пояса
+//
+// Используется стандарт ISO 8601 (hh:mm[:ss[.sss]])
+// Пример:
+//   «18:31:42» - «18 часов 31 минута 42 секунды»
+//
+// Поддерживает следующие форматы при Unmarshalling (XML/JSON/Text/SQL):
+//   15:04
+//   15:04:05
+//   15:04:05.999999999 - доли секунды, допускается запятая вместо точки
+//   24:00              - конец суток
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// oneDay это длительность суток
+const oneDay = 24 * time.Hour
+
+// NewTimeOfDay возвращает время суток
+func NewTimeOfDay(hour, minute, second, nanosecond int) (TimeOfDay, error) {
+	t := TimeOfDay{
+		Hour:       hour,
+		Minute:     minute,
+		Second:     second,
+		Nanosecond: nanosecond,
+	}
+	if !t.IsValid() {
+		return TimeOfDay{}, fmt.Errorf("times: invalid time of day %02d:%02d:%02d.%09d", hour, minute, second, nanosecond)
+	}
+	return t, nil
+}
+
+// TimeOfDayOf возвращает время суток метки времени в её часовом поясе
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	hour, minute, second := t.Clock()
+	return TimeOfDay{
+		Hour:       hour,
+		Minute:     minute,
+		Second:     second,
+		Nanosecond: t.Nanosecond(),
+	}
+}
+
+// ParseTimeOfDay возвращает время суток на основе строки
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	invalid := fmt.Errorf("times: cannot parse %q as time of day", s)
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return TimeOfDay{}, invalid
+	}
+	var values [3]int
+	var nanosecond int
+	for i, part := range parts {
+		if i == 2 {
+			if n := strings.IndexAny(part, ".,"); n >= 0 {
+				fraction := part[n+1:]
+				if fraction == "" || len(fraction) > 9 || !isDigits(fraction) {
+					return TimeOfDay{}, invalid
+				}
+				nanosecond, _ = strconv.Atoi(fraction + strings.Repeat("0", 9-len(fraction)))
+				part = part[:n]
+			}
+		}
+		if len(part) != 2 || !isDigits(part) {
+			return TimeOfDay{}, invalid
+		}
+		values[i], _ = strconv.Atoi(part)
+	}
+	t := TimeOfDay{
+		Hour:       values[0],
+		Minute:     values[1],
+		Second:     values[2],
+		Nanosecond: nanosecond,
+	}
+	if !t.IsValid() {
+		return TimeOfDay{}, invalid
+	}
+	return t, nil
+}
+
+// TimeOfDay возвращает время суток метки времени в её часовом поясе
+func (t Time) TimeOfDay() TimeOfDay {
+	return TimeOfDayOf(t.Time())
+}
+
+// IsValid возвращает true если время суток корректно
+// Час 24 допускается только как 24:00:00
+func (t TimeOfDay) IsValid() bool {
+	if t.Hour == 24 {
+		return t.Minute == 0 && t.Second == 0 && t.Nanosecond == 0
+	}
+	return t.Hour >= 0 && t.Hour < 24 &&
+		t.Minute >= 0 && t.Minute < 60 &&
+		t.Second >= 0 && t.Second < 60 &&
+		t.Nanosecond >= 0 && t.Nanosecond < int(time.Second)
+}
+
+// IsEndOfDay возвращает true для 24:00
+func (t TimeOfDay) IsEndOfDay() bool {
+	return t.Hour == 24
+}
+
+// SinceMidnight возвращает длительность от начала суток
+func (t TimeOfDay) SinceMidnight() time.Duration {
+	return time.Duration(t.Hour)*time.Hour +
+		time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second +
+		time.Duration(t.Nanosecond)
+}
+
+// timeOfDaySinceMidnight возвращает время суток по длительности от начала суток
+func timeOfDaySinceMidnight(d time.Duration) TimeOfDay {
+	return TimeOfDay{
+		Hour:       int(d / time.Hour),
+		Minute:     int(d % time.Hour / time.Minute),
+		Second:     int(d % time.Minute / time.Second),
+		Nanosecond: int(d % time.Second),
+	}
+}
+
+// Add возвращает t+duration с переходом через полночь
+// и количество пройденных полуночей (отрицательное при движении назад)
+func (t TimeOfDay) Add(duration time.Duration) (TimeOfDay, int) {
+	total := t.SinceMidnight() + duration
+	days := int(total / oneDay)
+	total %= oneDay
+	if total < 0 {
+		total += oneDay
+		days--
+	}
+	return timeOfDaySinceMidnight(total), days
+}
+
+// Compare возвращает -1, 0 или +1 если t раньше, равно или позже u
+func (t TimeOfDay) Compare(u TimeOfDay) int {
+	left := t.SinceMidnight()
+	right := u.SinceMidnight()
+	switch {
+	case left < right:
+		return -1
+	case left > right:
+		return +1
+	}
+	return 0
+}
+
+// Before возвращает true если t раньше u
+func (t TimeOfDay) Before(u TimeOfDay) bool {
+	return t.Compare(u) < 0
+}
+
+// After возвращает true если t позже u
+func (t TimeOfDay) After(u TimeOfDay) bool {
+	return t.Compare(u) > 0
+}
+
+// Equal возвращает true если время суток совпадает
+func (t TimeOfDay) Equal(u TimeOfDay) bool {
+	return t == u
+}
+
+// On возвращает метку времени в дату date в location
+// 24:00 соответствует началу следующего дня
+func (t TimeOfDay) On(date Date, location *time.Location) (*Time, error) {
+	if location == nil {
+		return nil, errors.New("times: empty time location")
+	}
+	result := Time(time.Date(
+		date.Year,
+		date.Month,
+		date.Day,
+		t.Hour,
+		t.Minute,
+		t.Second,
+		t.Nanosecond,
+		location,
+	))
+	return &result, nil
+}
+
+// String возвращает текстовое представление
+// Доли секунды выводятся только если они не нулевые
+func (t TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%09d", t.Nanosecond), "0")
+	}
+	return s
+}
+
+// MarshalText это реализация интерфейса encoding.TextMarshaler
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText это реализация интерфейса encoding.TextUnmarshaler
+func (t *TimeOfDay) UnmarshalText(data []byte) error {
+	return t.setTimeOfDayString(string(data))
+}
+
+// MarshalJSON необходим для кодирования времени суток
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON необходим для декодирования времени суток
+func (t *TimeOfDay) UnmarshalJSON(data []byte) error {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	return t.setTimeOfDayString(value)
+}
+
+// MarshalXML необходим для кодирования времени суток
+func (t TimeOfDay) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(t.String(), start)
+}
+
+// UnmarshalXML необходим для декодирования времени суток
+func (t *TimeOfDay) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var data string
+	err := d.DecodeElement(&data, &start)
+	if err != nil {
+		return err
+	}
+	return t.setTimeOfDayString(data)
+}
+
+// MarshalXMLAttr необходим для кодирования времени суток
+func (t TimeOfDay) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return xml.Attr{
+		Name:  name,
+		Value: t.String(),
+	}, nil
+}
+
+// UnmarshalXMLAttr необходим для декодирования времени суток
+func (t *TimeOfDay) UnmarshalXMLAttr(attr xml.Attr) error {
+	return t.setTimeOfDayString(attr.Value)
+}
+
+// Scan это реализация интерфейса database/sql.Scanner
+// Для time.Time используется время суток в часовом поясе значения
+func (t *TimeOfDay) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case time.Time:
+		*t = TimeOfDayOf(v)
+		return nil
+	case string:
+		return t.setTimeOfDayString(v)
+	case []byte:
+		return t.setTimeOfDayString(string(v))
+	}
+	return fmt.Errorf("times: expected value type time.Time, string or []byte but actual %T", src)
+}
+
+// Value это реализация database/sql/driver.Valuer
+// Возвращает строку для колонки типа TIME
+func (t TimeOfDay) Value() (driver.Value, error) {
+	return t.String(), nil
+}
+
+// setTimeOfDayString устанавливает время суток из строки
+// Пустая строка соответствует 00:00:00
+func (t *TimeOfDay) setTimeOfDayString(data string) error {
+	if data == "" {
+		*t = TimeOfDay{}
+		return nil
+	}
+	value, err := ParseTimeOfDay(data)
+	if err != nil {
+		return err
+	}
+	*t = value
+	return nil
+}
+
+// isDigits возвращает true если строка состоит только из цифр
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,113 @@
+package times
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTimeOfDay(t *testing.T) {
+	Convey("Проверяем разбор времени суток", t, func() {
+		testParseTimeOfDay("18:31", TimeOfDay{Hour: 18, Minute: 31})
+		testParseTimeOfDay("18:31:42", TimeOfDay{Hour: 18, Minute: 31, Second: 42})
+		testParseTimeOfDay("18:31:42.74", TimeOfDay{Hour: 18, Minute: 31, Second: 42, Nanosecond: 740000000})
+		testParseTimeOfDay("18:31:42,5", TimeOfDay{Hour: 18, Minute: 31, Second: 42, Nanosecond: 500000000})
+		testParseTimeOfDay("24:00", TimeOfDay{Hour: 24})
+		testParseTimeOfDay("24:00:00", TimeOfDay{Hour: 24})
+		Convey("Некорректные значения", func() {
+			for _, s := range []string{"24:01", "25:00", "18:60", "18:31:60", "18", "8:31", "18:31:42.", "18:31:42.1234567890"} {
+				_, err := ParseTimeOfDay(s)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+	Convey("Проверяем текстовое представление", t, func() {
+		So(TimeOfDay{Hour: 9, Minute: 5}.String(), ShouldEqual, "09:05:00")
+		So(TimeOfDay{Hour: 9, Minute: 5, Nanosecond: 740000000}.String(), ShouldEqual, "09:05:00.74")
+		So(TimeOfDay{Hour: 24}.String(), ShouldEqual, "24:00:00")
+	})
+	Convey("Проверяем сложение с переходом через полночь", t, func() {
+		start := TimeOfDay{Hour: 22, Minute: 30}
+
+		result, days := start.Add(time.Hour)
+		So(result, ShouldResemble, TimeOfDay{Hour: 23, Minute: 30})
+		So(days, ShouldEqual, 0)
+
+		result, days = start.Add(2 * time.Hour)
+		So(result, ShouldResemble, TimeOfDay{Hour: 0, Minute: 30})
+		So(days, ShouldEqual, 1)
+
+		result, days = start.Add(-23 * time.Hour)
+		So(result, ShouldResemble, TimeOfDay{Hour: 23, Minute: 30})
+		So(days, ShouldEqual, -1)
+
+		result, days = TimeOfDay{Hour: 24}.Add(0)
+		So(result, ShouldResemble, TimeOfDay{})
+		So(days, ShouldEqual, 1)
+	})
+	Convey("Проверяем сравнение", t, func() {
+		a := TimeOfDay{Hour: 9}
+		b := TimeOfDay{Hour: 18}
+		So(a.Before(b), ShouldBeTrue)
+		So(b.After(a), ShouldBeTrue)
+		So(a.Compare(a), ShouldEqual, 0)
+		So(TimeOfDay{Hour: 24}.After(TimeOfDay{Hour: 23, Minute: 59}), ShouldBeTrue)
+	})
+	Convey("Проверяем объединение с датой", t, func() {
+		date := NewDate(2018, time.February, 1)
+		result, err := TimeOfDay{Hour: 18, Minute: 31, Second: 42}.On(date, MoscowLocation)
+		So(err, ShouldBeNil)
+		So(result.String(), ShouldEqual, "2018-02-01T18:31:42+03:00")
+		So(result.TimeOfDay(), ShouldResemble, TimeOfDay{Hour: 18, Minute: 31, Second: 42})
+
+		result, err = TimeOfDay{Hour: 24}.On(date, MoscowLocation)
+		So(err, ShouldBeNil)
+		So(result.String(), ShouldEqual, "2018-02-02T00:00:00+03:00")
+	})
+	Convey("Проверяем кодирование", t, func() {
+		type Data struct {
+			XMLName xml.Name  `xml:"slot" json:"-"`
+			From    TimeOfDay `xml:"from,attr" json:"from"`
+			To      TimeOfDay `xml:"to" json:"to"`
+		}
+		data := Data{
+			From: TimeOfDay{Hour: 9},
+			To:   TimeOfDay{Hour: 24},
+		}
+		result, err := json.Marshal(data)
+		So(err, ShouldBeNil)
+		So(string(result), ShouldEqual, `{"from":"09:00:00","to":"24:00:00"}`)
+
+		result, err = xml.Marshal(data)
+		So(err, ShouldBeNil)
+		So(string(result), ShouldEqual, `<slot from="09:00:00"><to>24:00:00</to></slot>`)
+
+		var decoded Data
+		err = xml.Unmarshal([]byte(`<slot from="09:30"><to>18:00</to></slot>`), &decoded)
+		So(err, ShouldBeNil)
+		So(decoded.From, ShouldResemble, TimeOfDay{Hour: 9, Minute: 30})
+		So(decoded.To, ShouldResemble, TimeOfDay{Hour: 18})
+	})
+	Convey("Проверяем database/sql", t, func() {
+		var value TimeOfDay
+		So(value.Scan([]byte("18:31:42.5")), ShouldBeNil)
+		So(value, ShouldResemble, TimeOfDay{Hour: 18, Minute: 31, Second: 42, Nanosecond: 500000000})
+		So(value.Scan(time.Date(0, 1, 1, 9, 15, 0, 0, time.UTC)), ShouldBeNil)
+		So(value, ShouldResemble, TimeOfDay{Hour: 9, Minute: 15})
+
+		result, err := value.Value()
+		So(err, ShouldBeNil)
+		So(result, ShouldEqual, "09:15:00")
+	})
+}
+
+func testParseTimeOfDay(source string, expected TimeOfDay) {
+	Convey(source, func() {
+		result, err := ParseTimeOfDay(source)
+		So(err, ShouldBeNil)
+		So(result, ShouldResemble, expected)
+	})
+}
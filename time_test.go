@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -263,3 +264,117 @@ func testUntilEndNextMonthDays(startDate string, expected int) {
 		)
 	})
 }
+
+func TestTimeText(t *testing.T) {
+	Convey("Проверяем текстовое представление", t, func() {
+		Convey("Time", func() {
+			var date Time
+			err := date.UnmarshalText([]byte("2018-01-25T16:24:28+05:00"))
+			So(err, ShouldBeNil)
+			data, err := date.MarshalText()
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, "2018-01-25T11:24:28Z")
+		})
+		Convey("MoscowTime", func() {
+			var date MoscowTime
+			err := date.UnmarshalText([]byte("2018-01-25T16:24:28"))
+			So(err, ShouldBeNil)
+			So(date.String(), ShouldEqual, "2018-01-25T16:24:28+03:00")
+			data, err := date.MarshalText()
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, "2018-01-25T13:24:28Z")
+		})
+		Convey("Ключ JSON объекта", func() {
+			var data map[MoscowTime]int
+			err := json.Unmarshal([]byte(`{"2018-01-25T16:24:28":1}`), &data)
+			So(err, ShouldBeNil)
+			result, err := json.Marshal(data)
+			So(err, ShouldBeNil)
+			So(string(result), ShouldEqual, `{"2018-01-25T13:24:28Z":1}`)
+		})
+		Convey("Пользовательский формат", func() {
+			date, err := NewMoscowTimeString("2018-01-25T16:24:28")
+			So(err, ShouldBeNil)
+			data, err := date.CustomMarshalText(MoscowLocation, "02.01.2006 15:04")
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, "25.01.2018 16:24")
+			_, err = date.CustomMarshalText(nil, "02.01.2006 15:04")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestTimeScan(t *testing.T) {
+	Convey("Проверяем database/sql.Scanner", t, func() {
+		testScan(time.Date(2018, 1, 25, 16, 24, 28, 0, time.UTC), "2018-01-25T19:24:28+03:00")
+		testScan("2018-01-25T16:24:28", "2018-01-25T16:24:28+03:00")
+		testScan([]byte("2018-01-25 16:24:28"), "2018-01-25T16:24:28+03:00")
+		testScan("2018-01-25 16:24:28.74", "2018-01-25T16:24:28.74+03:00")
+		testScan("2018-01-25 16:24:28+05:00", "2018-01-25T14:24:28+03:00")
+		testScan("2018-01-25 16:24:28.74+05", "2018-01-25T14:24:28.74+03:00")
+		testScan(int64(1516886668), "2018-01-25T16:24:28+03:00")
+		testScan(2458144.1837037037, "2018-01-25T19:24:32+03:00")
+		Convey("Неподдерживаемый тип", func() {
+			var date MoscowTime
+			So(date.Scan(true), ShouldNotBeNil)
+			So(date.Scan(nil), ShouldNotBeNil)
+		})
+		Convey("Числовые метки времени", func() {
+			parser := NewParser().SetNumeric(UnixMillis, UnixSeconds)
+			var date Time
+			So(date.CustomScan(int64(1516886668740), MoscowLocation, parser), ShouldBeNil)
+			So(date.Time().Format(time.RFC3339Nano), ShouldEqual, "2018-01-25T16:24:28.74+03:00")
+			So(date.CustomScan(1516886668.5, MoscowLocation, parser), ShouldBeNil)
+			So(date.Time().Format(time.RFC3339Nano), ShouldEqual, "2018-01-25T16:24:28.5+03:00")
+
+			parser = parser.SetNumeric(UnixMicros, UnixNanos)
+			So(date.CustomScan(int64(1516886668740000), MoscowLocation, parser), ShouldBeNil)
+			So(date.Time().Format(time.RFC3339Nano), ShouldEqual, "2018-01-25T16:24:28.74+03:00")
+
+			parser = parser.SetNumeric(UnixNanos, UnixNanos)
+			So(date.CustomScan(int64(1516886668740000001), MoscowLocation, parser), ShouldBeNil)
+			So(date.Time().Format(time.RFC3339Nano), ShouldEqual, "2018-01-25T16:24:28.740000001+03:00")
+		})
+		Convey("Числовые метки времени после 2262 года", func() {
+			result, err := UnixSeconds.Float(1e10)
+			So(err, ShouldBeNil)
+			So(result.UTC().Format(time.RFC3339), ShouldEqual, "2286-11-20T17:46:40Z")
+			result, err = UnixMillis.Float(-1e13 - 0.5)
+			So(err, ShouldBeNil)
+			So(result.UTC().Format(time.RFC3339Nano), ShouldEqual, "1653-02-10T06:13:19.9995Z")
+			result, err = JulianDays.Float(5373484.5)
+			So(err, ShouldBeNil)
+			So(result.UTC().Format(time.RFC3339), ShouldEqual, "10000-01-01T00:00:00Z")
+
+			for _, v := range []float64{1e20, -1e20, math.Inf(1), math.NaN()} {
+				_, err = UnixSeconds.Float(v)
+				So(err, ShouldNotBeNil)
+			}
+			_, err = JulianDays.Int(math.MaxInt64)
+			So(err, ShouldNotBeNil)
+			_, err = NewParser().SetNumeric(UnixSeconds, UnixMillis).ParseFloat(1e20, time.UTC)
+			So(err, ShouldNotBeNil)
+		})
+		Convey("Нулевое значение Parser", func() {
+			parser := (&Parser{}).Add("2006-01-02 15:04:05", 0)
+			var date Time
+			So(date.CustomScan(2458144.1837037037, MoscowLocation, parser), ShouldBeNil)
+			So(date.Time().Format(time.RFC3339), ShouldEqual, "2018-01-25T19:24:32+03:00")
+			So(date.CustomScan(int64(1516886668), MoscowLocation, parser), ShouldBeNil)
+			So(date.Time().Format(time.RFC3339), ShouldEqual, "2018-01-25T16:24:28+03:00")
+		})
+	})
+}
+
+func testScan(src interface{}, expected string) {
+	Convey(fmt.Sprintf("%T %v", src, src), func() {
+		var date MoscowTime
+		err := date.Scan(src)
+		So(err, ShouldBeNil)
+		So(
+			date.Time.Time().Format(time.RFC3339Nano),
+			ShouldEqual,
+			expected,
+		)
+	})
+}
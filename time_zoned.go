@@ -0,0 +1,131 @@
+package times
+
+import (
+	"database/sql/driver"
+	"encoding/xml"
+	"time"
+)
+
+// Zone описывает часовые пояса и формат метки времени Zoned
+//
+// Методы вызываются на нулевом значении типа, поэтому
+// реализация обычно является пустой структурой:
+//   type Yekb struct{}
+//
+//   func (Yekb) Location() *time.Location       { return yekbLocation }
+//   func (Yekb) OutputLocation() *time.Location { return time.UTC }
+//   func (Yekb) Layout() string                 { return time.RFC3339 }
+//
+//   type YekaterinburgTime = times.Zoned[Yekb]
+type Zone interface {
+	// Location возвращает часовой пояс по умолчанию для входящих значений
+	Location() *time.Location
+
+	// OutputLocation возвращает часовой пояс при кодировании
+	OutputLocation() *time.Location
+
+	// Layout возвращает формат при кодировании
+	Layout() string
+}
+
+// Zoned это метка времени с часовым поясом по умолчанию из Z
+// Особенности:
+//   Принимает любую локаль и преобразует в Z.Location()
+//   В XML, JSON и текстовом виде возвращает время в Z.OutputLocation() в формате Z.Layout()
+type Zoned[Z Zone] struct {
+	Time
+}
+
+// NewZoned возвращает метку времени в часовом поясе Z.Location()
+func NewZoned[Z Zone](t time.Time) (*Zoned[Z], error) {
+	var zone Z
+	date, err := NewTime(t, zone.Location())
+	if err != nil {
+		return nil, err
+	}
+	return &Zoned[Z]{
+		Time: *date,
+	}, nil
+}
+
+// NewZonedString возвращает метку времени на основе строки в часовом поясе Z.Location()
+func NewZonedString[Z Zone](s string) (*Zoned[Z], error) {
+	var zone Z
+	t, err := NewTimeString(s, zone.Location())
+	if err != nil {
+		return nil, err
+	}
+	return &Zoned[Z]{
+		Time: *t,
+	}, nil
+}
+
+// String возвращает текстовое представление с учётом PrecisionZone
+func (t Zoned[Z]) String() string {
+	var zone Z
+	return t.Format(zoneLayout(zone, "2006-01-02T15:04:05Z07:00"))
+}
+
+// MarshalXML необходим для кодирования даты и времени
+func (t Zoned[Z]) MarshalXML(d *xml.Encoder, start xml.StartElement) error {
+	var zone Z
+	return t.CustomMarshalXML(d, start, zone.OutputLocation(), zoneLayout(zone, zone.Layout()))
+}
+
+// MarshalXMLAttr необходим для кодирования даты и времени
+func (t Zoned[Z]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	var zone Z
+	return t.CustomMarshalXMLAttr(name, zone.OutputLocation(), zoneLayout(zone, zone.Layout()))
+}
+
+// UnmarshalXML необходим для декодирования даты и времени
+func (t *Zoned[Z]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var zone Z
+	return t.CustomUnmarshalXML(d, start, zone.Location())
+}
+
+// UnmarshalXMLAttr необходим для декодирования даты и времени
+func (t *Zoned[Z]) UnmarshalXMLAttr(attr xml.Attr) error {
+	var zone Z
+	return t.CustomUnmarshalXMLAttr(attr, zone.Location())
+}
+
+// MarshalJSON необходим для кодирования даты и времени
+func (t Zoned[Z]) MarshalJSON() ([]byte, error) {
+	var zone Z
+	return t.CustomMarshalJSON(zone.OutputLocation(), zoneLayout(zone, zone.Layout()))
+}
+
+// UnmarshalJSON необходим для декодирования даты и времени
+func (t *Zoned[Z]) UnmarshalJSON(data []byte) error {
+	var zone Z
+	return t.CustomUnmarshalJSON(data, zone.Location())
+}
+
+// MarshalText это реализация интерфейса encoding.TextMarshaler
+func (t Zoned[Z]) MarshalText() ([]byte, error) {
+	var zone Z
+	return t.CustomMarshalText(zone.OutputLocation(), zoneLayout(zone, zone.Layout()))
+}
+
+// UnmarshalText это реализация интерфейса encoding.TextUnmarshaler
+func (t *Zoned[Z]) UnmarshalText(data []byte) error {
+	var zone Z
+	return t.CustomUnmarshalText(data, zone.Location())
+}
+
+// Scan это реализация интерфейса database/sql.Scanner
+func (t *Zoned[Z]) Scan(src interface{}) error {
+	var zone Z
+	return t.CustomScan(src, zone.Location())
+}
+
+// Value это реализация database/sql/driver.Valuer
+// Параметры задаются Z, если Z реализует ValueZone
+func (t Zoned[Z]) Value() (driver.Value, error) {
+	var zone Z
+	if v, ok := interface{}(zone).(ValueZone); ok {
+		return t.CustomValue(v.ValueLocation(), v.ValuePrecision(), v.ValueLayout())
+	}
+	return t.Time.Value()
+}
@@ -0,0 +1,106 @@
+package times
+
+import (
+	"fmt"
+	"time"
+)
+
+// Boundary возвращает начало периода, следующего за n-м периодом после периода t
+// n = 0 - текущий период, n = 1 - следующий и т.д.
+// Граница вычисляется по календарю в часовом поясе t
+type Boundary func(t Time, n int) Time
+
+var (
+	// EndOfDay это конец дня
+	EndOfDay Boundary = endOfDay
+
+	// EndOfWeek это конец недели, начинающейся с понедельника
+	EndOfWeek = EndOfWeekStarting(time.Monday)
+
+	// EndOfMonth это конец месяца
+	EndOfMonth = EndOfMonths(1, time.January)
+
+	// EndOfQuarter это конец квартала
+	EndOfQuarter = EndOfMonths(3, time.January)
+
+	// EndOfHalfYear это конец полугодия
+	EndOfHalfYear = EndOfMonths(6, time.January)
+
+	// EndOfYear это конец года
+	EndOfYear = EndOfMonths(12, time.January)
+)
+
+// endOfDay возвращает начало дня, следующего за n-м днём после дня t
+func endOfDay(t Time, n int) Time {
+	year, month, day := t.Time().Date()
+	return t.startOf(year, month, day+n+1)
+}
+
+// EndOfWeekStarting возвращает конец недели, начинающейся с weekStart
+func EndOfWeekStarting(weekStart time.Weekday) Boundary {
+	return func(t Time, n int) Time {
+		year, month, day := t.Time().Date()
+		return t.startOf(year, month, day-t.weekOffset([]time.Weekday{weekStart})+7*(n+1))
+	}
+}
+
+// EndOfMonths возвращает конец периода из months месяцев,
+// один из периодов начинается с месяца first
+// Пример:
+//   EndOfMonths(12, time.April) - конец финансового года, начинающегося 1 апреля
+//   EndOfMonths(3, time.April)  - конец квартала этого финансового года
+// Паникует если months меньше 1
+func EndOfMonths(months int, first time.Month) Boundary {
+	if months < 1 {
+		panic(fmt.Sprintf("times: invalid period of %d months", months))
+	}
+	return func(t Time, n int) Time {
+		year, month, _ := t.Time().Date()
+		index := year*12 + int(month-first)
+		start := index - ((index%months)+months)%months
+		next := start + months*(n+1) + int(first-1)
+		return t.startOf(next/12, time.Month(next%12+1), 1)
+	}
+}
+
+// Remaining это время от метки времени до конца периода
+type Remaining struct {
+	// From это исходная метка времени
+	From Time
+
+	// To это начало следующего периода
+	To Time
+}
+
+// Until возвращает время до конца n-го периода после текущего
+// Пример:
+//   t.Until(times.EndOfMonth, 0).Days()     - дней до конца текущего месяца
+//   t.Until(times.EndOfQuarter, 1).Days()   - дней до конца следующего квартала
+//   t.Until(times.EndOfYear, 0).Duration()  - точное время до конца года
+func (t Time) Until(boundary Boundary, n int) Remaining {
+	return Remaining{
+		From: t,
+		To:   boundary(t, n),
+	}
+}
+
+// End возвращает последнюю наносекунду периода
+func (r Remaining) End() Time {
+	return r.To.Add(-time.Nanosecond)
+}
+
+// Days возвращает количество календарных дней от даты From включительно до конца периода
+// Дни считаются по календарю, поэтому переход на летнее и зимнее время не влияет на результат
+func (r Remaining) Days() int {
+	return r.To.Date().DaysSince(r.From.Date())
+}
+
+// Workdays возвращает количество рабочих дней от даты From включительно до конца периода
+func (r Remaining) Workdays(calendar Calendar) (int, error) {
+	return r.From.WorkdaysBetween(calendar, r.To)
+}
+
+// Duration возвращает точное время от From до конца периода
+func (r Remaining) Duration() time.Duration {
+	return r.To.Time().Sub(r.From.Time())
+}
@@ -0,0 +1,55 @@
+package times
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUntil(t *testing.T) {
+	Convey("Проверяем время до конца периода", t, func() {
+		value := Time(time.Date(2018, 8, 15, 13, 0, 0, 0, MoscowLocation))
+
+		testUntil(value, EndOfDay, 0, "2018-08-16T00:00:00+03:00", 1)
+		testUntil(value, EndOfDay, 2, "2018-08-18T00:00:00+03:00", 3)
+		testUntil(value, EndOfWeek, 0, "2018-08-20T00:00:00+03:00", 5)
+		testUntil(value, EndOfWeekStarting(time.Sunday), 0, "2018-08-19T00:00:00+03:00", 4)
+		testUntil(value, EndOfMonth, 0, "2018-09-01T00:00:00+03:00", 17)
+		testUntil(value, EndOfMonth, 3, "2018-12-01T00:00:00+03:00", 108)
+		testUntil(value, EndOfMonth, -1, "2018-08-01T00:00:00+03:00", -14)
+		testUntil(value, EndOfQuarter, 0, "2018-10-01T00:00:00+03:00", 47)
+		testUntil(value, EndOfQuarter, 1, "2019-01-01T00:00:00+03:00", 139)
+		testUntil(value, EndOfHalfYear, 0, "2019-01-01T00:00:00+03:00", 139)
+		testUntil(value, EndOfYear, 0, "2019-01-01T00:00:00+03:00", 139)
+		testUntil(value, EndOfMonths(12, time.April), 0, "2019-04-01T00:00:00+03:00", 229)
+		testUntil(value, EndOfMonths(3, time.February), 0, "2018-11-01T00:00:00+03:00", 78)
+
+		remaining := value.Until(EndOfDay, 0)
+		So(remaining.Duration(), ShouldEqual, 11*time.Hour)
+		So(remaining.End().Time().Format(time.RFC3339Nano), ShouldEqual, "2018-08-15T23:59:59.999999999+03:00")
+		workdays, err := remaining.Workdays(Weekends)
+		So(err, ShouldBeNil)
+		So(workdays, ShouldEqual, 1)
+		workdays, err = value.Until(EndOfMonth, 0).Workdays(Weekends)
+		So(err, ShouldBeNil)
+		So(workdays, ShouldEqual, 13)
+
+		So(func() { EndOfMonths(0, time.January) }, ShouldPanic)
+	})
+	Convey("Переход на летнее время не влияет на количество дней", t, func() {
+		location, err := time.LoadLocation("Europe/Berlin")
+		So(err, ShouldBeNil)
+		value := Time(time.Date(2018, 3, 1, 0, 30, 0, 0, location))
+		remaining := value.Until(EndOfMonth, 0)
+		So(remaining.Days(), ShouldEqual, 31)
+		So(remaining.Duration(), ShouldEqual, 31*24*time.Hour-time.Hour-30*time.Minute)
+		So(value.UntilEndMonthDays(), ShouldEqual, 31)
+	})
+}
+
+func testUntil(value Time, boundary Boundary, n int, end string, days int) {
+	remaining := value.Until(boundary, n)
+	So(remaining.To.String(), ShouldEqual, end)
+	So(remaining.Days(), ShouldEqual, days)
+}
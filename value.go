@@ -0,0 +1,50 @@
+package times
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+)
+
+// ValueZone это необязательное расширение Zone,
+// задающее параметры database/sql/driver.Valuer для Zoned и NullZoned
+//
+// Без реализации ValueZone в базу данных передаётся исходный time.Time
+type ValueZone interface {
+	Zone
+
+	// ValueLocation возвращает часовой пояс значения для базы данных
+	ValueLocation() *time.Location
+
+	// ValuePrecision возвращает точность значения для базы данных,
+	// например time.Second, 0 - без усечения
+	ValuePrecision() time.Duration
+
+	// ValueLayout возвращает формат строки для базы данных,
+	// пустая строка - передаётся time.Time
+	ValueLayout() string
+}
+
+// CustomValue это реализация database/sql/driver.Valuer
+// Время приводится к location и усекается до precision (0 - без усечения)
+// Если format не пустой - возвращается строка в этом формате, иначе time.Time
+func (t Time) CustomValue(
+	location *time.Location,
+	precision time.Duration,
+	format string,
+) (
+	driver.Value,
+	error,
+) {
+	if location == nil {
+		return nil, errors.New("times: empty time location")
+	}
+	value := t.Time().In(location)
+	if precision > 0 {
+		value = value.Truncate(precision)
+	}
+	if format != "" {
+		return value.Format(format), nil
+	}
+	return value, nil
+}
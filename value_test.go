@@ -0,0 +1,70 @@
+package times
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// mysqlMoscow сохраняет MoscowTime в MySQL DATETIME в UTC с точностью до секунды
+type mysqlMoscow struct {
+	Moscow
+}
+
+func (mysqlMoscow) ValueLocation() *time.Location {
+	return time.UTC
+}
+
+func (mysqlMoscow) ValuePrecision() time.Duration {
+	return time.Second
+}
+
+func (mysqlMoscow) ValueLayout() string {
+	return "2006-01-02 15:04:05"
+}
+
+func TestValue(t *testing.T) {
+	source := time.Date(2018, time.January, 25, 16, 24, 28, 740000000, MoscowLocation)
+	Convey("Проверяем CustomValue", t, func() {
+		date := Time(source)
+
+		value, err := date.CustomValue(time.UTC, time.Millisecond, "")
+		So(err, ShouldBeNil)
+		So(value, ShouldResemble, time.Date(2018, time.January, 25, 13, 24, 28, 740000000, time.UTC))
+
+		value, err = date.CustomValue(MoscowLocation, time.Second, "2006-01-02 15:04:05")
+		So(err, ShouldBeNil)
+		So(value, ShouldEqual, "2018-01-25 16:24:28")
+
+		_, err = date.CustomValue(nil, 0, "")
+		So(err, ShouldNotBeNil)
+	})
+	Convey("Проверяем ValueZone", t, func() {
+		Convey("Без ValueZone передаётся исходное время", func() {
+			date, err := NewMoscowTime(source)
+			So(err, ShouldBeNil)
+			value, err := date.Value()
+			So(err, ShouldBeNil)
+			So(value, ShouldResemble, source)
+		})
+		Convey("С ValueZone", func() {
+			date, err := NewZoned[mysqlMoscow](source)
+			So(err, ShouldBeNil)
+			value, err := date.Value()
+			So(err, ShouldBeNil)
+			So(value, ShouldEqual, "2018-01-25 13:24:28")
+		})
+		Convey("NullZoned", func() {
+			var date NullZoned[mysqlMoscow]
+			value, err := date.Value()
+			So(err, ShouldBeNil)
+			So(value, ShouldBeNil)
+
+			So(date.Scan(source), ShouldBeNil)
+			value, err = date.Value()
+			So(err, ShouldBeNil)
+			So(value, ShouldEqual, "2018-01-25 13:24:28")
+		})
+	})
+}
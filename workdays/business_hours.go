@@ -0,0 +1,462 @@
+package workdays
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mantyr/times"
+)
+
+// maxIdleDays это количество дней подряд без рабочего времени,
+// после которого поиск рабочего времени прекращается
+const maxIdleDays = 3660
+
+// ErrNoBusinessHours возвращают NextOpening и Add,
+// если рабочего времени нет или его недостаточно
+var ErrNoBusinessHours = errors.New("workdays: no business hours")
+
+// ShortCalendar это календарь с сокращёнными предпраздничными днями
+// В сокращённый день последняя смена заканчивается на час раньше
+type ShortCalendar interface {
+	IsShort(date times.Date) bool
+}
+
+// Shift это рабочая смена внутри дня, например 09:00-18:00
+// End может быть 24:00
+type Shift struct {
+	Start times.TimeOfDay
+	End   times.TimeOfDay
+}
+
+// ParseShift разбирает смену в формате hh:mm-hh:mm
+func ParseShift(s string) (Shift, error) {
+	i := strings.IndexByte(s, '-')
+	if i < 0 {
+		return Shift{}, fmt.Errorf("workdays: cannot parse %q as shift", s)
+	}
+	start, err := times.ParseTimeOfDay(strings.TrimSpace(s[:i]))
+	if err != nil {
+		return Shift{}, err
+	}
+	end, err := times.ParseTimeOfDay(strings.TrimSpace(s[i+1:]))
+	if err != nil {
+		return Shift{}, err
+	}
+	shift := Shift{Start: start, End: end}
+	if !start.Before(end) {
+		return Shift{}, fmt.Errorf("workdays: shift %q ends before it starts", s)
+	}
+	return shift, nil
+}
+
+// Duration возвращает продолжительность смены
+func (s Shift) Duration() time.Duration {
+	return s.End.SinceMidnight() - s.Start.SinceMidnight()
+}
+
+// String возвращает смену в формате hh:mm-hh:mm
+// Секунды выводятся только если они не нулевые
+func (s Shift) String() string {
+	return formatShiftTime(s.Start) + "-" + formatShiftTime(s.End)
+}
+
+// formatShiftTime возвращает время в формате hh:mm или hh:mm:ss
+func formatShiftTime(t times.TimeOfDay) string {
+	if t.Second == 0 && t.Nanosecond == 0 {
+		return fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+	}
+	return t.String()
+}
+
+// MarshalText это реализация интерфейса encoding.TextMarshaler
+func (s Shift) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText это реализация интерфейса encoding.TextUnmarshaler
+func (s *Shift) UnmarshalText(data []byte) error {
+	shift, err := ParseShift(string(data))
+	if err != nil {
+		return err
+	}
+	*s = shift
+	return nil
+}
+
+// BusinessHours это рабочее время в часовом поясе
+//
+// Рабочие дни определяет календарь, по умолчанию times.Weekends.
+// Рабочий день использует часы своего дня недели, если они заданы, иначе общие часы.
+// Если календарь реализует ShortCalendar, в сокращённый день
+// последняя смена заканчивается на час раньше.
+// Если календарь реализует times.BoundedCalendar, для дат вне его лет
+// методы возвращают *times.CalendarRangeError
+//
+// Нулевое значение это рабочее время без часов по календарю times.Weekends в UTC
+//
+// Пример: «с понедельника по пятницу с 9:00 до 18:00 по Москве без праздников»
+//   hours, err := workdays.NewBusinessHours(times.MoscowLocation, workdays.Russia(), "09:00-18:00")
+//
+// Конфигурация кодируется в JSON и YAML без календаря:
+//   {"location":"Europe/Moscow","hours":["09:00-13:00","14:00-18:00"],"weekdays":{"friday":["09:00-16:45"]}}
+type BusinessHours struct {
+	location *time.Location
+	calendar times.Calendar
+	hours    []Shift
+	weekdays map[time.Weekday][]Shift
+}
+
+// NewBusinessHours возвращает рабочее время с общими часами hours в формате hh:mm-hh:mm
+// Пустой calendar означает times.Weekends
+func NewBusinessHours(location *time.Location, calendar times.Calendar, hours ...string) (*BusinessHours, error) {
+	if location == nil {
+		return nil, errors.New("workdays: empty time location")
+	}
+	b := &BusinessHours{
+		location: location,
+		weekdays: map[time.Weekday][]Shift{},
+	}
+	b.SetCalendar(calendar)
+	shifts, err := parseShifts(hours)
+	if err != nil {
+		return nil, err
+	}
+	b.hours = shifts
+	return b, nil
+}
+
+// parseShifts разбирает и проверяет смены
+func parseShifts(hours []string) ([]Shift, error) {
+	shifts := make([]Shift, 0, len(hours))
+	for _, s := range hours {
+		shift, err := ParseShift(s)
+		if err != nil {
+			return nil, err
+		}
+		shifts = append(shifts, shift)
+	}
+	return shifts, checkShifts(shifts)
+}
+
+// checkShifts проверяет, что смены идут по порядку и не пересекаются
+func checkShifts(shifts []Shift) error {
+	for i, shift := range shifts {
+		if !shift.Start.IsValid() || !shift.End.IsValid() || !shift.Start.Before(shift.End) {
+			return fmt.Errorf("workdays: invalid shift %s", shift)
+		}
+		if i > 0 && shift.Start.Before(shifts[i-1].End) {
+			return fmt.Errorf("workdays: shift %s overlaps %s", shift, shifts[i-1])
+		}
+	}
+	return nil
+}
+
+// SetCalendar устанавливает календарь рабочих дней, nil означает times.Weekends
+func (b *BusinessHours) SetCalendar(calendar times.Calendar) {
+	if calendar == nil {
+		calendar = times.Weekends
+	}
+	b.calendar = calendar
+}
+
+// SetWeekday устанавливает часы дня недели в формате hh:mm-hh:mm
+// Без hours рабочие дни этого дня недели не имеют рабочего времени
+func (b *BusinessHours) SetWeekday(weekday time.Weekday, hours ...string) error {
+	shifts, err := parseShifts(hours)
+	if err != nil {
+		return err
+	}
+	if b.weekdays == nil {
+		b.weekdays = map[time.Weekday][]Shift{}
+	}
+	b.weekdays[weekday] = shifts
+	return nil
+}
+
+// Location возвращает часовой пояс рабочего времени
+func (b *BusinessHours) Location() *time.Location {
+	if b.location == nil {
+		return time.UTC
+	}
+	return b.location
+}
+
+// workdays возвращает календарь рабочих дней
+func (b *BusinessHours) workdays() times.Calendar {
+	if b.calendar == nil {
+		return times.Weekends
+	}
+	return b.calendar
+}
+
+// period это рабочий промежуток [start, end)
+type period struct {
+	start time.Time
+	end   time.Time
+}
+
+// periods возвращает рабочие промежутки даты
+func (b *BusinessHours) periods(date times.Date) ([]period, error) {
+	calendar := b.workdays()
+	if bounded, ok := calendar.(times.BoundedCalendar); ok && !bounded.Covers(date.Year) {
+		return nil, &times.CalendarRangeError{
+			Year: date.Year,
+		}
+	}
+	if !calendar.IsWorkday(date) {
+		return nil, nil
+	}
+	location := b.Location()
+	shifts, ok := b.weekdays[date.Weekday()]
+	if !ok {
+		shifts = b.hours
+	}
+	result := make([]period, 0, len(shifts))
+	for _, shift := range shifts {
+		start, _ := shift.Start.On(date, location)
+		end, _ := shift.End.On(date, location)
+		result = append(result, period{start: start.Time(), end: end.Time()})
+	}
+	if short, ok := calendar.(ShortCalendar); ok && len(result) > 0 && short.IsShort(date) {
+		last := &result[len(result)-1]
+		last.end = last.end.Add(-time.Hour)
+		if !last.end.After(last.start) {
+			result = result[:len(result)-1]
+		}
+	}
+	return result, nil
+}
+
+// date возвращает дату t в часовом поясе рабочего времени
+func (b *BusinessHours) date(t time.Time) times.Date {
+	return times.DateOf(t.In(b.Location()))
+}
+
+// IsOpen возвращает true если t попадает в рабочее время
+func (b *BusinessHours) IsOpen(t times.Time) (bool, error) {
+	periods, err := b.periods(b.date(t.Time()))
+	if err != nil {
+		return false, err
+	}
+	for _, p := range periods {
+		if !t.Time().Before(p.start) && t.Time().Before(p.end) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// NextOpening возвращает ближайший момент рабочего времени не раньше t
+// Если t попадает в рабочее время, возвращается t
+// Если рабочего времени нет, возвращается ErrNoBusinessHours
+func (b *BusinessHours) NextOpening(t times.Time) (times.Time, error) {
+	from := t.Time()
+	date := b.date(from)
+	for idle := 0; idle < maxIdleDays; idle++ {
+		periods, err := b.periods(date)
+		if err != nil {
+			return times.Time{}, err
+		}
+		for _, p := range periods {
+			if from.Before(p.end) {
+				if from.Before(p.start) {
+					return times.Time(p.start), nil
+				}
+				return times.Time(from.In(b.Location())), nil
+			}
+		}
+		date = date.AddDays(1)
+	}
+	return times.Time{}, ErrNoBusinessHours
+}
+
+// Between возвращает продолжительность рабочего времени между start и end
+// Если end раньше start, результат отрицательный
+func (b *BusinessHours) Between(start, end times.Time) (time.Duration, error) {
+	from, to := start.Time(), end.Time()
+	sign := time.Duration(1)
+	if to.Before(from) {
+		from, to, sign = to, from, -1
+	}
+	var result time.Duration
+	last := b.date(to)
+	for date := b.date(from); !date.After(last); date = date.AddDays(1) {
+		periods, err := b.periods(date)
+		if err != nil {
+			return 0, err
+		}
+		for _, p := range periods {
+			s, e := p.start, p.end
+			if s.Before(from) {
+				s = from
+			}
+			if e.After(to) {
+				e = to
+			}
+			if e.After(s) {
+				result += e.Sub(s)
+			}
+		}
+	}
+	return sign * result, nil
+}
+
+// Add возвращает момент через d рабочего времени после t
+// Отрицательное d отсчитывается назад. Если рабочее время заканчивается
+// ровно в конце смены, возвращается конец смены
+// Если рабочего времени недостаточно, возвращается ErrNoBusinessHours
+func (b *BusinessHours) Add(t times.Time, d time.Duration) (times.Time, error) {
+	if d < 0 {
+		return b.sub(t, -d)
+	}
+	from := t.Time()
+	date := b.date(from)
+	for idle := 0; idle < maxIdleDays; idle++ {
+		periods, err := b.periods(date)
+		if err != nil {
+			return times.Time{}, err
+		}
+		for _, p := range periods {
+			if !from.Before(p.end) {
+				continue
+			}
+			if from.Before(p.start) {
+				from = p.start
+			}
+			available := p.end.Sub(from)
+			if d <= available {
+				return times.Time(from.Add(d).In(b.Location())), nil
+			}
+			d -= available
+			from = p.end
+			idle = 0
+		}
+		date = date.AddDays(1)
+	}
+	return times.Time{}, ErrNoBusinessHours
+}
+
+// sub возвращает момент за d рабочего времени до t
+func (b *BusinessHours) sub(t times.Time, d time.Duration) (times.Time, error) {
+	to := t.Time()
+	date := b.date(to)
+	for idle := 0; idle < maxIdleDays; idle++ {
+		periods, err := b.periods(date)
+		if err != nil {
+			return times.Time{}, err
+		}
+		for i := len(periods) - 1; i >= 0; i-- {
+			p := periods[i]
+			if !p.start.Before(to) {
+				continue
+			}
+			if to.After(p.end) {
+				to = p.end
+			}
+			available := to.Sub(p.start)
+			if d <= available {
+				return times.Time(to.Add(-d).In(b.Location())), nil
+			}
+			d -= available
+			to = p.start
+			idle = 0
+		}
+		date = date.AddDays(-1)
+	}
+	return times.Time{}, ErrNoBusinessHours
+}
+
+// businessHoursConfig это конфигурация рабочего времени для кодирования
+type businessHoursConfig struct {
+	Location string             `json:"location" yaml:"location"`
+	Hours    []Shift            `json:"hours" yaml:"hours"`
+	Weekdays map[string][]Shift `json:"weekdays,omitempty" yaml:"weekdays,omitempty"`
+}
+
+// config возвращает конфигурацию рабочего времени
+func (b *BusinessHours) config() businessHoursConfig {
+	config := businessHoursConfig{
+		Location: b.Location().String(),
+		Hours:    append([]Shift{}, b.hours...),
+	}
+	if len(b.weekdays) > 0 {
+		config.Weekdays = map[string][]Shift{}
+		for weekday, shifts := range b.weekdays {
+			config.Weekdays[strings.ToLower(weekday.String())] = append([]Shift{}, shifts...)
+		}
+	}
+	return config
+}
+
+// setConfig устанавливает рабочее время из конфигурации, календарь не меняется
+func (b *BusinessHours) setConfig(config businessHoursConfig) error {
+	location, err := times.LoadLocation(config.Location)
+	if err != nil {
+		return err
+	}
+	err = checkShifts(config.Hours)
+	if err != nil {
+		return err
+	}
+	weekdays := map[time.Weekday][]Shift{}
+	for name, shifts := range config.Weekdays {
+		weekday, err := parseWeekday(name)
+		if err != nil {
+			return err
+		}
+		err = checkShifts(shifts)
+		if err != nil {
+			return err
+		}
+		weekdays[weekday] = shifts
+	}
+	b.location = location
+	b.hours = config.Hours
+	b.weekdays = weekdays
+	return nil
+}
+
+// parseWeekday возвращает день недели по английскому названию
+func parseWeekday(name string) (time.Weekday, error) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(weekday.String(), name) {
+			return weekday, nil
+		}
+	}
+	return 0, fmt.Errorf("workdays: unknown weekday %q", name)
+}
+
+// MarshalJSON необходим для кодирования конфигурации рабочего времени
+func (b BusinessHours) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.config())
+}
+
+// UnmarshalJSON необходим для декодирования конфигурации рабочего времени
+func (b *BusinessHours) UnmarshalJSON(data []byte) error {
+	var config businessHoursConfig
+	err := json.Unmarshal(data, &config)
+	if err != nil {
+		return err
+	}
+	return b.setConfig(config)
+}
+
+// MarshalYAML необходим для кодирования конфигурации рабочего времени
+// (интерфейс yaml.Marshaler пакетов gopkg.in/yaml.v2 и gopkg.in/yaml.v3)
+func (b BusinessHours) MarshalYAML() (interface{}, error) {
+	return b.config(), nil
+}
+
+// UnmarshalYAML необходим для декодирования конфигурации рабочего времени
+// (интерфейс yaml.Unmarshaler пакета gopkg.in/yaml.v2, поддерживается и в gopkg.in/yaml.v3)
+func (b *BusinessHours) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var config businessHoursConfig
+	err := unmarshal(&config)
+	if err != nil {
+		return err
+	}
+	return b.setConfig(config)
+}
@@ -0,0 +1,194 @@
+package workdays
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mantyr/times"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBusinessHours(t *testing.T) {
+	Convey("Проверяем рабочее время", t, func() {
+		hours, err := NewBusinessHours(times.MoscowLocation, Russia(), "09:00-13:00", "14:00-18:00")
+		So(err, ShouldBeNil)
+		So(hours.Location(), ShouldEqual, times.MoscowLocation)
+
+		testIsOpen(hours, testMoscow(2018, 3, 6, 9, 0), true)
+		testIsOpen(hours, testMoscow(2018, 3, 6, 13, 30), false)
+		testIsOpen(hours, testMoscow(2018, 3, 6, 18, 0), false)
+		testIsOpen(hours, testMoscow(2018, 3, 8, 10, 0), false)
+
+		Convey("Продолжительность рабочего времени", func() {
+			testBetween(hours, testMoscow(2018, 3, 6, 8, 0), testMoscow(2018, 3, 6, 20, 0), 8*time.Hour)
+			testBetween(hours, testMoscow(2018, 3, 6, 12, 0), testMoscow(2018, 3, 6, 15, 0), 2*time.Hour)
+			// 7 марта сокращённый день, 8 и 9 марта праздники
+			testBetween(hours, testMoscow(2018, 3, 7, 0, 0), testMoscow(2018, 3, 12, 10, 0), 8*time.Hour)
+			testBetween(hours, testMoscow(2018, 3, 6, 15, 0), testMoscow(2018, 3, 6, 12, 0), -2*time.Hour)
+		})
+		Convey("Прибавление рабочего времени", func() {
+			testAdd(hours, testMoscow(2018, 3, 6, 16, 0), 4*time.Hour, "2018-03-07T11:00:00+03:00")
+			testAdd(hours, testMoscow(2018, 3, 6, 12, 0), 2*time.Hour, "2018-03-06T15:00:00+03:00")
+			testAdd(hours, testMoscow(2018, 3, 6, 14, 0), 4*time.Hour, "2018-03-06T18:00:00+03:00")
+			testAdd(hours, testMoscow(2018, 3, 7, 16, 0), time.Hour+time.Minute, "2018-03-12T09:01:00+03:00")
+			testAdd(hours, testMoscow(2018, 3, 10, 12, 0), 0, "2018-03-12T09:00:00+03:00")
+			testAdd(hours, testMoscow(2018, 3, 12, 10, 0), -2*time.Hour, "2018-03-07T16:00:00+03:00")
+			testAdd(hours, testMoscow(2018, 3, 6, 14, 0), -time.Hour, "2018-03-06T12:00:00+03:00")
+		})
+		Convey("Ближайшее рабочее время", func() {
+			next, err := hours.NextOpening(testMoscow(2018, 3, 7, 17, 30))
+			So(err, ShouldBeNil)
+			So(next.String(), ShouldEqual, "2018-03-12T09:00:00+03:00")
+
+			next, err = hours.NextOpening(times.Time(time.Date(2018, 3, 6, 7, 0, 0, 0, time.UTC)))
+			So(err, ShouldBeNil)
+			So(next.String(), ShouldEqual, "2018-03-06T10:00:00+03:00")
+		})
+		Convey("Даты вне лет календаря", func() {
+			var rangeErr *times.CalendarRangeError
+			_, err := hours.IsOpen(testMoscow(2030, 3, 6, 10, 0))
+			So(errors.As(err, &rangeErr), ShouldBeTrue)
+			So(rangeErr.Year, ShouldEqual, 2030)
+
+			_, err = hours.Between(testMoscow(2026, 12, 30, 0, 0), testMoscow(2027, 1, 12, 0, 0))
+			So(errors.As(err, &rangeErr), ShouldBeTrue)
+			So(rangeErr.Year, ShouldEqual, 2027)
+
+			_, err = hours.NextOpening(testMoscow(2026, 12, 31, 19, 0))
+			So(errors.As(err, &rangeErr), ShouldBeTrue)
+			_, err = hours.Add(testMoscow(2026, 12, 31, 10, 0), 24*time.Hour)
+			So(errors.As(err, &rangeErr), ShouldBeTrue)
+			_, err = hours.Add(testMoscow(2018, 1, 9, 10, 0), -24*time.Hour)
+			So(errors.As(err, &rangeErr), ShouldBeTrue)
+			So(rangeErr.Year, ShouldEqual, 2017)
+		})
+	})
+	Convey("Проверяем часы дня недели", t, func() {
+		hours, err := NewBusinessHours(times.MoscowLocation, nil, "09:00-18:00")
+		So(err, ShouldBeNil)
+		So(hours.SetWeekday(time.Friday, "09:00-16:45"), ShouldBeNil)
+		testBetween(hours, testMoscow(2018, 3, 5, 0, 0), testMoscow(2018, 3, 12, 0, 0), 4*9*time.Hour+7*time.Hour+45*time.Minute)
+		testIsOpen(hours, testMoscow(2018, 3, 8, 10, 0), true)
+
+		So(hours.SetWeekday(time.Monday, "18:00-09:00"), ShouldNotBeNil)
+		So(hours.SetWeekday(time.Monday, "09:00-13:00", "12:00-18:00"), ShouldNotBeNil)
+
+		empty, err := NewBusinessHours(times.MoscowLocation, nil)
+		So(err, ShouldBeNil)
+		_, err = empty.NextOpening(testMoscow(2018, 3, 5, 0, 0))
+		So(err, ShouldEqual, ErrNoBusinessHours)
+		_, err = empty.Add(testMoscow(2018, 3, 5, 0, 0), time.Hour)
+		So(err, ShouldEqual, ErrNoBusinessHours)
+
+		_, err = NewBusinessHours(nil, nil)
+		So(err, ShouldNotBeNil)
+		_, err = NewBusinessHours(times.MoscowLocation, nil, "9-18")
+		So(err, ShouldNotBeNil)
+	})
+	Convey("Проверяем нулевое значение", t, func() {
+		var hours BusinessHours
+		So(hours.Location(), ShouldEqual, time.UTC)
+		testIsOpen(&hours, testMoscow(2018, 3, 6, 10, 0), false)
+		_, err := hours.NextOpening(testMoscow(2018, 3, 6, 10, 0))
+		So(err, ShouldEqual, ErrNoBusinessHours)
+
+		So(hours.SetWeekday(time.Monday, "09:00-18:00"), ShouldBeNil)
+		// 5 марта 2018 понедельник
+		next, err := hours.NextOpening(testMoscow(2018, 3, 5, 10, 0))
+		So(err, ShouldBeNil)
+		So(next.String(), ShouldEqual, "2018-03-05T09:00:00Z")
+		testBetween(&hours, testMoscow(2018, 3, 5, 0, 0), testMoscow(2018, 3, 12, 0, 0), 9*time.Hour)
+
+		data, err := json.Marshal(hours)
+		So(err, ShouldBeNil)
+		So(string(data), ShouldEqual, `{"location":"UTC","hours":[],"weekdays":{"monday":["09:00-18:00"]}}`)
+	})
+	Convey("Проверяем кодирование конфигурации", t, func() {
+		hours, err := NewBusinessHours(times.MoscowLocation, nil, "09:00-13:00", "14:00-18:00")
+		So(err, ShouldBeNil)
+		So(hours.SetWeekday(time.Friday, "09:00-16:45"), ShouldBeNil)
+
+		source := `{"location":"Europe/Moscow","hours":["09:00-13:00","14:00-18:00"],"weekdays":{"friday":["09:00-16:45"]}}`
+		data, err := json.Marshal(hours)
+		So(err, ShouldBeNil)
+		So(string(data), ShouldEqual, source)
+
+		var decoded BusinessHours
+		err = json.Unmarshal(data, &decoded)
+		So(err, ShouldBeNil)
+		testBetween(&decoded, testMoscow(2018, 3, 9, 0, 0), testMoscow(2018, 3, 10, 0, 0), 7*time.Hour+45*time.Minute)
+
+		for _, source := range []string{
+			`{"location":"Mars/Olympus","hours":["09:00-18:00"]}`,
+			`{"location":"UTC","hours":["18:00-09:00"]}`,
+			`{"location":"UTC","hours":["09:00-18:00"],"weekdays":{"funday":[]}}`,
+		} {
+			err = json.Unmarshal([]byte(source), &decoded)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
+
+func TestBusinessHoursYAML(t *testing.T) {
+	Convey("Проверяем кодирование конфигурации в YAML", t, func() {
+		hours, err := NewBusinessHours(times.MoscowLocation, nil, "09:00-13:00", "14:00-18:00")
+		So(err, ShouldBeNil)
+		So(hours.SetWeekday(time.Friday, "09:00-16:45"), ShouldBeNil)
+
+		value, err := hours.MarshalYAML()
+		So(err, ShouldBeNil)
+		config, ok := value.(businessHoursConfig)
+		So(ok, ShouldBeTrue)
+		So(config.Location, ShouldEqual, "Europe/Moscow")
+		So(config.Hours, ShouldResemble, []Shift{
+			{Start: times.TimeOfDay{Hour: 9}, End: times.TimeOfDay{Hour: 13}},
+			{Start: times.TimeOfDay{Hour: 14}, End: times.TimeOfDay{Hour: 18}},
+		})
+		So(config.Weekdays, ShouldResemble, map[string][]Shift{
+			"friday": {{Start: times.TimeOfDay{Hour: 9}, End: times.TimeOfDay{Hour: 16, Minute: 45}}},
+		})
+
+		var decoded BusinessHours
+		err = decoded.UnmarshalYAML(func(v interface{}) error {
+			*v.(*businessHoursConfig) = config
+			return nil
+		})
+		So(err, ShouldBeNil)
+		So(decoded.Location().String(), ShouldEqual, "Europe/Moscow")
+		testBetween(&decoded, testMoscow(2018, 3, 9, 0, 0), testMoscow(2018, 3, 10, 0, 0), 7*time.Hour+45*time.Minute)
+
+		err = decoded.UnmarshalYAML(func(v interface{}) error {
+			*v.(*businessHoursConfig) = businessHoursConfig{Location: "UTC", Hours: []Shift{config.Hours[1], config.Hours[0]}}
+			return nil
+		})
+		So(err, ShouldNotBeNil)
+		err = decoded.UnmarshalYAML(func(v interface{}) error {
+			return errors.New("yaml: unmarshal errors")
+		})
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func testMoscow(year int, month time.Month, day, hour, minute int) times.Time {
+	return times.Time(time.Date(year, month, day, hour, minute, 0, 0, times.MoscowLocation))
+}
+
+func testAdd(hours *BusinessHours, t times.Time, d time.Duration, expected string) {
+	result, err := hours.Add(t, d)
+	So(err, ShouldBeNil)
+	So(result.String(), ShouldEqual, expected)
+}
+
+func testIsOpen(hours *BusinessHours, t times.Time, expected bool) {
+	result, err := hours.IsOpen(t)
+	So(err, ShouldBeNil)
+	So(result, ShouldEqual, expected)
+}
+
+func testBetween(hours *BusinessHours, start, end times.Time, expected time.Duration) {
+	result, err := hours.Between(start, end)
+	So(err, ShouldBeNil)
+	So(result, ShouldEqual, expected)
+}
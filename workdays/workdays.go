@@ -0,0 +1,256 @@
+// Package workdays реализует производственный календарь
+// и содержит календарь Российской Федерации
+package workdays
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mantyr/times"
+)
+
+// Calendar это производственный календарь
+type Calendar = times.Calendar
+
+// Kind это вид дня производственного календаря
+type Kind int
+
+const (
+	// Workday это рабочий день
+	Workday Kind = iota
+
+	// Holiday это нерабочий день: выходной, праздник или перенесённый выходной
+	Holiday
+
+	// Short это предпраздничный рабочий день, сокращённый на один час
+	Short
+)
+
+var kindNames = []string{"workday", "holiday", "short"}
+
+// String возвращает название вида дня
+func (k Kind) String() string {
+	if k < Workday || k > Short {
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+	return kindNames[k]
+}
+
+// MarshalText это реализация интерфейса encoding.TextMarshaler
+func (k Kind) MarshalText() ([]byte, error) {
+	if k < Workday || k > Short {
+		return nil, fmt.Errorf("workdays: unknown kind %d", int(k))
+	}
+	return []byte(kindNames[k]), nil
+}
+
+// UnmarshalText это реализация интерфейса encoding.TextUnmarshaler
+func (k *Kind) UnmarshalText(data []byte) error {
+	name := strings.ToLower(strings.TrimSpace(string(data)))
+	for i, kind := range kindNames {
+		if kind == name {
+			*k = Kind(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("workdays: unknown kind %q", string(data))
+}
+
+// ProductionCalendar это производственный календарь с исключениями
+// Дни без исключений считаются рабочими с понедельника по пятницу
+//
+// Календарь может быть ограничен годами, за которые загружены данные, см. Covers,
+// тогда функции рабочих дней пакета times возвращают *times.CalendarRangeError
+// для остальных лет вместо учёта только суббот и воскресений
+//
+// Исключения загружаются из JSON:
+//   {"2018-01-01": "holiday", "2018-04-28": "short"}
+// или из CSV со строкой заголовка и комментариями через #:
+//   date,kind
+//   2018-01-01,holiday
+//   2018-04-28,short
+type ProductionCalendar struct {
+	days map[times.Date]Kind
+
+	// years это годы, за которые есть данные, nil - без ограничения
+	years map[int]bool
+}
+
+// New возвращает календарь без праздников, не ограниченный годами
+func New() *ProductionCalendar {
+	return &ProductionCalendar{
+		days: map[times.Date]Kind{},
+	}
+}
+
+//go:embed ru.csv
+var russiaData string
+
+var (
+	russia     *ProductionCalendar
+	russiaOnce sync.Once
+)
+
+// Russia возвращает копию производственного календаря Российской Федерации
+// Встроенные данные охватывают 2018-2026 годы, для других лет
+// функции рабочих дней возвращают ошибку, пока данные не загружены через ReadJSON или ReadCSV
+func Russia() *ProductionCalendar {
+	russiaOnce.Do(func() {
+		russia = New()
+		russia.years = map[int]bool{}
+		err := russia.ReadCSV(strings.NewReader(russiaData))
+		if err != nil {
+			panic(err)
+		}
+	})
+	return russia.Clone()
+}
+
+// Clone возвращает копию календаря
+func (c *ProductionCalendar) Clone() *ProductionCalendar {
+	result := New()
+	for date, kind := range c.days {
+		result.days[date] = kind
+	}
+	if c.years != nil {
+		result.years = map[int]bool{}
+		for year := range c.years {
+			result.years[year] = true
+		}
+	}
+	return result
+}
+
+// Cover ограничивает календарь годами, за которые есть данные, и добавляет к ним years
+// ReadJSON и ReadCSV добавляют годы загруженных исключений автоматически
+func (c *ProductionCalendar) Cover(years ...int) {
+	if c.years == nil {
+		c.years = map[int]bool{}
+	}
+	for _, year := range years {
+		c.years[year] = true
+	}
+}
+
+// Covers возвращает true если календарь содержит данные за год
+// Календарь без ограничения содержит данные за любой год
+func (c *ProductionCalendar) Covers(year int) bool {
+	return c.years == nil || c.years[year]
+}
+
+// Years возвращает годы, за которые есть данные, по возрастанию
+// Для календаря без ограничения возвращает nil
+func (c *ProductionCalendar) Years() []int {
+	if c.years == nil {
+		return nil
+	}
+	result := make([]int, 0, len(c.years))
+	for year := range c.years {
+		result = append(result, year)
+	}
+	sort.Ints(result)
+	return result
+}
+
+// setDays добавляет исключения и годы их дат для ограниченного календаря
+func (c *ProductionCalendar) setDays(days map[times.Date]Kind) {
+	for date, kind := range days {
+		c.days[date] = kind
+		if c.years != nil {
+			c.years[date.Year] = true
+		}
+	}
+}
+
+// Set устанавливает вид дня
+func (c *ProductionCalendar) Set(date times.Date, kind Kind) {
+	c.days[date] = kind
+}
+
+// Kind возвращает вид дня
+// Для года без данных (см. Covers) учитываются только суббота и воскресенье
+func (c *ProductionCalendar) Kind(date times.Date) Kind {
+	if kind, ok := c.days[date]; ok {
+		return kind
+	}
+	switch date.Weekday() {
+	case time.Saturday, time.Sunday:
+		return Holiday
+	}
+	return Workday
+}
+
+// IsWorkday возвращает true если дата является рабочим днём
+func (c *ProductionCalendar) IsWorkday(date times.Date) bool {
+	return c.Kind(date) != Holiday
+}
+
+// IsShort возвращает true если дата является сокращённым рабочим днём
+func (c *ProductionCalendar) IsShort(date times.Date) bool {
+	return c.Kind(date) == Short
+}
+
+// Exceptions возвращает исключения календаря по возрастанию даты
+func (c *ProductionCalendar) Exceptions() []times.Date {
+	result := make([]times.Date, 0, len(c.days))
+	for date := range c.days {
+		result = append(result, date)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Before(result[j])
+	})
+	return result
+}
+
+// ReadJSON добавляет исключения из JSON, существующие исключения заменяются
+func (c *ProductionCalendar) ReadJSON(r io.Reader) error {
+	var days map[times.Date]Kind
+	err := json.NewDecoder(r).Decode(&days)
+	if err != nil {
+		return err
+	}
+	c.setDays(days)
+	return nil
+}
+
+// WriteJSON записывает исключения в JSON
+func (c *ProductionCalendar) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(c.days)
+}
+
+// ReadCSV добавляет исключения из CSV, существующие исключения заменяются
+func (c *ProductionCalendar) ReadCSV(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+	days := map[times.Date]Kind{}
+	for i, record := range records {
+		if i == 0 && record[0] == "date" {
+			continue
+		}
+		date, err := times.ParseDate(record[0])
+		if err != nil {
+			return err
+		}
+		var kind Kind
+		err = kind.UnmarshalText([]byte(record[1]))
+		if err != nil {
+			return err
+		}
+		days[date] = kind
+	}
+	c.setDays(days)
+	return nil
+}
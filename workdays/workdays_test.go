@@ -0,0 +1,141 @@
+package workdays
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mantyr/times"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRussia(t *testing.T) {
+	Convey("Проверяем количество рабочих дней в году", t, func() {
+		calendar := Russia()
+		for year, expected := range map[int]int{
+			2018: 247,
+			2019: 247,
+			2020: 246, // с учётом нерабочих дней 24 июня и 1 июля
+			2021: 247,
+			2022: 247,
+			2023: 247,
+			2024: 248,
+			2025: 247,
+			2026: 247,
+		} {
+			start := times.Time(time.Date(year, time.January, 1, 0, 0, 0, 0, times.MoscowLocation))
+			end := times.Time(time.Date(year, time.December, 31, 0, 0, 0, 0, times.MoscowLocation))
+			workdays, err := start.WorkdaysBetween(calendar, end)
+			So(err, ShouldBeNil)
+			if calendar.IsWorkday(end.Date()) {
+				workdays++
+			}
+			So(workdays, ShouldEqual, expected)
+		}
+	})
+	Convey("Проверяем годы встроенных данных", t, func() {
+		calendar := Russia()
+		So(calendar.Years(), ShouldResemble, []int{2018, 2019, 2020, 2021, 2022, 2023, 2024, 2025, 2026})
+		So(calendar.Covers(2026), ShouldBeTrue)
+		So(calendar.Covers(2017), ShouldBeFalse)
+		So(calendar.Covers(2027), ShouldBeFalse)
+		So(calendar.IsWorkday(times.NewDate(2026, time.January, 9)), ShouldBeFalse)
+
+		t := times.Time(time.Date(2026, time.December, 30, 10, 0, 0, 0, times.MoscowLocation))
+		_, err := t.AddWorkdays(calendar, 1)
+		var rangeErr *times.CalendarRangeError
+		So(errors.As(err, &rangeErr), ShouldBeTrue)
+		So(rangeErr.Year, ShouldEqual, 2027)
+
+		err = calendar.ReadCSV(strings.NewReader("2027-01-01,holiday\n"))
+		So(err, ShouldBeNil)
+		So(calendar.Covers(2027), ShouldBeTrue)
+		So(Russia().Covers(2027), ShouldBeFalse)
+
+		So(New().Covers(2027), ShouldBeTrue)
+		So(New().Years(), ShouldBeNil)
+		custom := New()
+		custom.Cover(2030)
+		So(custom.Covers(2030), ShouldBeTrue)
+		So(custom.Covers(2031), ShouldBeFalse)
+	})
+	Convey("Проверяем виды дней", t, func() {
+		calendar := Russia()
+		So(calendar.Kind(times.NewDate(2018, time.March, 8)), ShouldEqual, Holiday)
+		So(calendar.Kind(times.NewDate(2018, time.March, 7)), ShouldEqual, Short)
+		So(calendar.Kind(times.NewDate(2018, time.April, 28)), ShouldEqual, Short)
+		So(calendar.IsWorkday(times.NewDate(2018, time.April, 28)), ShouldBeTrue)
+		So(calendar.IsShort(times.NewDate(2018, time.April, 28)), ShouldBeTrue)
+		So(calendar.Kind(times.NewDate(2024, time.December, 28)), ShouldEqual, Workday)
+		So(calendar.Kind(times.NewDate(2018, time.March, 10)), ShouldEqual, Holiday)
+		So(calendar.Kind(times.NewDate(2018, time.March, 12)), ShouldEqual, Workday)
+		So(Short.String(), ShouldEqual, "short")
+	})
+	Convey("Проверяем арифметику рабочих дней", t, func() {
+		calendar := Russia()
+		t := times.Time(time.Date(2018, time.March, 7, 10, 0, 0, 0, times.MoscowLocation))
+		next, err := t.AddWorkdays(calendar, 1)
+		So(err, ShouldBeNil)
+		So(next.String(), ShouldEqual, "2018-03-12T10:00:00+03:00")
+		workdays, err := t.UntilEndMonthWorkdays(calendar)
+		So(err, ShouldBeNil)
+		So(workdays, ShouldEqual, 16)
+
+		friday := times.Time(time.Date(2018, time.April, 27, 10, 0, 0, 0, times.MoscowLocation))
+		next, err = friday.AddWorkdays(calendar, 1)
+		So(err, ShouldBeNil)
+		So(next.String(), ShouldEqual, "2018-04-28T10:00:00+03:00")
+		next, err = friday.AddWorkdays(calendar, 2)
+		So(err, ShouldBeNil)
+		So(next.String(), ShouldEqual, "2018-05-03T10:00:00+03:00")
+	})
+	Convey("Russia возвращает независимые копии", t, func() {
+		date := times.NewDate(2018, time.March, 12)
+		calendar := Russia()
+		calendar.Set(date, Holiday)
+		So(calendar.IsWorkday(date), ShouldBeFalse)
+		So(Russia().IsWorkday(date), ShouldBeTrue)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	Convey("Проверяем загрузку из JSON", t, func() {
+		calendar := New()
+		err := calendar.ReadJSON(strings.NewReader(`{"2026-01-09":"holiday","2026-01-10":"workday","2026-01-08":"short"}`))
+		So(err, ShouldBeNil)
+		So(calendar.IsWorkday(times.NewDate(2026, time.January, 9)), ShouldBeFalse)
+		So(calendar.IsWorkday(times.NewDate(2026, time.January, 10)), ShouldBeTrue)
+		So(calendar.Exceptions(), ShouldResemble, []times.Date{
+			times.NewDate(2026, time.January, 8),
+			times.NewDate(2026, time.January, 9),
+			times.NewDate(2026, time.January, 10),
+		})
+
+		var buffer bytes.Buffer
+		err = calendar.WriteJSON(&buffer)
+		So(err, ShouldBeNil)
+		So(buffer.String(), ShouldEqual, `{"2026-01-08":"short","2026-01-09":"holiday","2026-01-10":"workday"}`+"\n")
+
+		err = calendar.ReadJSON(strings.NewReader(`{"2026-01-09":"day off"}`))
+		So(err, ShouldNotBeNil)
+		err = calendar.ReadJSON(strings.NewReader(`{"2026-13-09":"holiday"}`))
+		So(err, ShouldNotBeNil)
+	})
+	Convey("Проверяем загрузку из CSV с заменой встроенных данных", t, func() {
+		calendar := Russia()
+		err := calendar.ReadCSV(strings.NewReader("# перенос\ndate,kind\n2018-03-09, workday\n"))
+		So(err, ShouldBeNil)
+		So(calendar.IsWorkday(times.NewDate(2018, time.March, 9)), ShouldBeTrue)
+
+		for _, source := range []string{
+			"2018-03-09",
+			"2018-03-32,holiday",
+			"2018-03-09,vacation",
+		} {
+			err := New().ReadCSV(strings.NewReader(source))
+			So(err, ShouldNotBeNil)
+		}
+	})
+}